@@ -2,41 +2,217 @@ package v1alpha3
 
 import (
 	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/utils/ptr"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
 	etcdv1beta1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdv1beta2 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 )
 
-// ConvertTo converts this EtcdadmConfig to the Hub version (v1beta1).
+// v1alpha3 objects are converted to and from the Hub version (v1beta2) through v1beta1. The fields that do not exist
+// in v1alpha3 are kept in the conversion data annotation on down-conversion and restored from it on up-conversion.
+
+// ConvertTo converts this EtcdadmConfig to the Hub version (v1beta2).
 func (src *EtcdadmConfig) ConvertTo(dstRaw conversion.Hub) error { // nolint
-	dst := dstRaw.(*etcdv1beta1.EtcdadmConfig)
-	if err := Convert_v1alpha3_EtcdadmConfig_To_v1beta1_EtcdadmConfig(src, dst, nil); err != nil {
+	dst := dstRaw.(*etcdv1beta2.EtcdadmConfig)
+	intermediate := &etcdv1beta1.EtcdadmConfig{}
+	if err := Convert_v1alpha3_EtcdadmConfig_To_v1beta1_EtcdadmConfig(src, intermediate, nil); err != nil {
+		return err
+	}
+	if err := intermediate.ConvertTo(dst); err != nil {
 		return err
 	}
+
+	// Manually restore data.
+	restored := &etcdv1beta2.EtcdadmConfig{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	delete(dst.Annotations, utilconversion.DataAnnotation)
+	restoreEtcdadmConfigSpec(&restored.Spec, &dst.Spec)
+	restoreEtcdadmConfigStatus(&restored.Status, &dst.Status)
 	return nil
 }
 
-// ConvertFrom converts from the Hub version (v1beta1) to this EtcdadmConfig.
+// ConvertFrom converts from the Hub version (v1beta2) to this EtcdadmConfig.
 func (dst *EtcdadmConfig) ConvertFrom(srcRaw conversion.Hub) error { // nolint
-	src := srcRaw.(*etcdv1beta1.EtcdadmConfig)
-	return Convert_v1beta1_EtcdadmConfig_To_v1alpha3_EtcdadmConfig(src, dst, nil)
+	src := srcRaw.(*etcdv1beta2.EtcdadmConfig)
+	intermediate := &etcdv1beta1.EtcdadmConfig{}
+	if err := intermediate.ConvertFrom(src); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_EtcdadmConfig_To_v1alpha3_EtcdadmConfig(intermediate, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion.
+	return utilconversion.MarshalData(src, dst)
 }
 
-// ConvertTo converts this EtcdadmConfigList to the Hub version (v1beta1).
+// ConvertTo converts this EtcdadmConfigList to the Hub version (v1beta2).
 func (src *EtcdadmConfigList) ConvertTo(dstRaw conversion.Hub) error { // nolint
-	dst := dstRaw.(*etcdv1beta1.EtcdadmConfigList)
-	if err := Convert_v1alpha3_EtcdadmConfigList_To_v1beta1_EtcdadmConfigList(src, dst, nil); err != nil {
-		return err
+	dst := dstRaw.(*etcdv1beta2.EtcdadmConfigList)
+	dst.ListMeta = src.ListMeta
+	dst.Items = nil
+	if src.Items != nil {
+		dst.Items = make([]etcdv1beta2.EtcdadmConfig, len(src.Items))
+	}
+	for i := range src.Items {
+		if err := src.Items[i].ConvertTo(&dst.Items[i]); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// ConvertFrom converts from the Hub version (v1beta1) to this EtcdadmConfigList.
+// ConvertFrom converts from the Hub version (v1beta2) to this EtcdadmConfigList.
 func (dst *EtcdadmConfigList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
-	src := srcRaw.(*etcdv1beta1.EtcdadmConfigList)
-	return Convert_v1beta1_EtcdadmConfigList_To_v1alpha3_EtcdadmConfigList(src, dst, nil)
+	src := srcRaw.(*etcdv1beta2.EtcdadmConfigList)
+	dst.ListMeta = src.ListMeta
+	dst.Items = nil
+	if src.Items != nil {
+		dst.Items = make([]EtcdadmConfig, len(src.Items))
+	}
+	for i := range src.Items {
+		if err := dst.Items[i].ConvertFrom(&src.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertTo converts this EtcdadmConfigTemplate to the Hub version (v1beta2).
+func (src *EtcdadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*etcdv1beta2.EtcdadmConfigTemplate)
+	intermediate := &etcdv1beta1.EtcdadmConfigTemplate{}
+	if err := Convert_v1alpha3_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(src, intermediate, nil); err != nil {
+		return err
+	}
+	if err := intermediate.ConvertTo(dst); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &etcdv1beta2.EtcdadmConfigTemplate{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	delete(dst.Annotations, utilconversion.DataAnnotation)
+	dst.Spec.Template.ObjectMeta = restored.Spec.Template.ObjectMeta
+	restoreEtcdadmConfigSpec(&restored.Spec.Template.Spec, &dst.Spec.Template.Spec)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta2) to this EtcdadmConfigTemplate.
+func (dst *EtcdadmConfigTemplate) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*etcdv1beta2.EtcdadmConfigTemplate)
+	intermediate := &etcdv1beta1.EtcdadmConfigTemplate{}
+	if err := intermediate.ConvertFrom(src); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_EtcdadmConfigTemplate_To_v1alpha3_EtcdadmConfigTemplate(intermediate, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion.
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this EtcdadmConfigTemplateList to the Hub version (v1beta2).
+func (src *EtcdadmConfigTemplateList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*etcdv1beta2.EtcdadmConfigTemplateList)
+	dst.ListMeta = src.ListMeta
+	dst.Items = nil
+	if src.Items != nil {
+		dst.Items = make([]etcdv1beta2.EtcdadmConfigTemplate, len(src.Items))
+	}
+	for i := range src.Items {
+		if err := src.Items[i].ConvertTo(&dst.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta2) to this EtcdadmConfigTemplateList.
+func (dst *EtcdadmConfigTemplateList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*etcdv1beta2.EtcdadmConfigTemplateList)
+	dst.ListMeta = src.ListMeta
+	dst.Items = nil
+	if src.Items != nil {
+		dst.Items = make([]EtcdadmConfigTemplate, len(src.Items))
+	}
+	for i := range src.Items {
+		if err := dst.Items[i].ConvertFrom(&src.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreEtcdadmConfigSpec restores the spec fields that do not exist in v1alpha3.
+func restoreEtcdadmConfigSpec(restored, dst *etcdv1beta2.EtcdadmConfigSpec) {
+	dst.Backup = restored.Backup
+	dst.RestoreFrom = restored.RestoreFrom
+	dst.JoinAsLearner = restored.JoinAsLearner
+	dst.APIServerEtcdClientCertificate = restored.APIServerEtcdClientCertificate
+	dst.Network = restored.Network
+	dst.Kernel = restored.Kernel
+	if dst.BottlerocketConfig != nil && restored.BottlerocketConfig != nil {
+		dst.BottlerocketConfig.Settings = restored.BottlerocketConfig.Settings
+		dst.BottlerocketConfig.LearnerImage = restored.BottlerocketConfig.LearnerImage
+	}
+	if dst.Proxy != nil && restored.Proxy != nil {
+		dst.Proxy.AutoNoProxy = restored.Proxy.AutoNoProxy
+	}
+	if dst.RegistryMirror != nil && restored.RegistryMirror != nil {
+		dst.RegistryMirror.Mirrors = restored.RegistryMirror.Mirrors
+		dst.RegistryMirror.CredentialsRef = restored.RegistryMirror.CredentialsRef
+	}
+	if dst.CloudInitConfig != nil && restored.CloudInitConfig != nil {
+		dst.CloudInitConfig.ContainerdVersion = restored.CloudInitConfig.ContainerdVersion
+	}
+}
+
+// restoreEtcdadmConfigStatus restores the status fields that do not exist in v1alpha3, as well as the ones that
+// v1alpha3 cannot tell apart from their zero value, as long as the v1alpha3 object did not change them.
+func restoreEtcdadmConfigStatus(restored, dst *etcdv1beta2.EtcdadmConfigStatus) {
+	dst.Conditions = restored.Conditions
+	dst.ObservedGeneration = restored.ObservedGeneration
+	if ptr.Deref(dst.Initialization.DataSecretCreated, false) == ptr.Deref(restored.Initialization.DataSecretCreated, false) {
+		dst.Initialization.DataSecretCreated = restored.Initialization.DataSecretCreated
+	}
+	if dst.Deprecated == nil && restored.Deprecated != nil &&
+		(restored.Deprecated.V1Beta1 == nil || len(restored.Deprecated.V1Beta1.Conditions) == 0) {
+		dst.Deprecated = restored.Deprecated
+	}
 }
 
 func Convert_v1beta1_BottlerocketConfig_To_v1alpha3_BottlerocketConfig(in *etcdv1beta1.BottlerocketConfig, out *BottlerocketConfig, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_BottlerocketConfig_To_v1alpha3_BottlerocketConfig(in, out, s)
 }
+
+func Convert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration(in *etcdv1beta1.ProxyConfiguration, out *ProxyConfiguration, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration(in, out, s)
+}
+
+func Convert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration(in *etcdv1beta1.RegistryMirrorConfiguration, out *RegistryMirrorConfiguration, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration(in, out, s)
+}
+
+func Convert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig(in *etcdv1beta1.CloudInitConfig, out *CloudInitConfig, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig(in, out, s)
+}
+
+func Convert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec(in *etcdv1beta1.EtcdadmConfigSpec, out *EtcdadmConfigSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec(in, out, s)
+}
+
+func Convert_v1beta1_EtcdadmConfigTemplateResource_To_v1alpha3_EtcdadmConfigTemplateResource(in *etcdv1beta1.EtcdadmConfigTemplateResource, out *EtcdadmConfigTemplateResource, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigTemplateResource_To_v1alpha3_EtcdadmConfigTemplateResource(in, out, s)
+}
+
+func Convert_v1beta1_EtcdadmConfigStatus_To_v1alpha3_EtcdadmConfigStatus(in *etcdv1beta1.EtcdadmConfigStatus, out *EtcdadmConfigStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigStatus_To_v1alpha3_EtcdadmConfigStatus(in, out, s)
+}
@@ -0,0 +1,80 @@
+//go:build !race
+
+package v1alpha3
+
+import (
+	"encoding/json"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/randfill"
+
+	etcdv1beta2 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+)
+
+// Test is disabled when the race detector is enabled (via "//go:build !race" above) because otherwise the fuzz tests would just time out.
+
+func TestFuzzyConversion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := etcdv1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("for EtcdadmConfig", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme:      scheme,
+		Hub:         &etcdv1beta2.EtcdadmConfig{},
+		Spoke:       &EtcdadmConfig{},
+		FuzzerFuncs: []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+	t.Run("for EtcdadmConfigList", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme:                     scheme,
+		Hub:                        &etcdv1beta2.EtcdadmConfigList{},
+		Spoke:                      &EtcdadmConfigList{},
+		SkipSpokeAnnotationCleanup: true,
+		SpokeAfterMutation:         cleanupListItemAnnotations,
+		FuzzerFuncs:                []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+	t.Run("for EtcdadmConfigTemplate", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme:      scheme,
+		Hub:         &etcdv1beta2.EtcdadmConfigTemplate{},
+		Spoke:       &EtcdadmConfigTemplate{},
+		FuzzerFuncs: []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+}
+
+func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
+	return []interface{}{
+		spokeEtcdadmConfigStatus,
+		hubBottlerocketSettings,
+	}
+}
+
+// hubBottlerocketSettings fills the Bottlerocket settings with a JSON object encoded the way the API server stores it.
+func hubBottlerocketSettings(in *apiextensionsv1.JSON, c randfill.Continue) {
+	in.Raw, _ = json.Marshal(map[string]interface{}{"kernel": map[string]string{"lockdown": c.String(0)}})
+}
+
+// spokeEtcdadmConfigStatus drops an empty data secret name, which the hub cannot tell apart from a missing one.
+func spokeEtcdadmConfigStatus(in *EtcdadmConfigStatus, c randfill.Continue) {
+	c.FillNoCustom(in)
+
+	if in.DataSecretName != nil && *in.DataSecretName == "" {
+		in.DataSecretName = nil
+	}
+}
+
+// cleanupListItemAnnotations removes the conversion data annotation ConvertFrom adds to the items of a list.
+func cleanupListItemAnnotations(convertible conversion.Convertible) {
+	list := convertible.(*EtcdadmConfigList)
+	for i := range list.Items {
+		delete(list.Items[i].Annotations, utilconversion.DataAnnotation)
+	}
+}
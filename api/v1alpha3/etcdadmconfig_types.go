@@ -32,7 +32,6 @@ const (
 )
 
 // Format specifies the output format of the bootstrap data
-// +kubebuilder:validation:Enum=cloud-config;bottlerocket
 type Format string
 
 // EtcdadmConfigSpec defines the desired state of EtcdadmConfig
@@ -68,11 +68,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.CloudInitConfig)(nil), (*CloudInitConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig(a.(*v1beta1.CloudInitConfig), b.(*CloudInitConfig), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*EtcdadmConfig)(nil), (*v1beta1.EtcdadmConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_EtcdadmConfig_To_v1beta1_EtcdadmConfig(a.(*EtcdadmConfig), b.(*v1beta1.EtcdadmConfig), scope)
 	}); err != nil {
@@ -98,38 +93,53 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.EtcdadmConfigSpec)(nil), (*EtcdadmConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec(a.(*v1beta1.EtcdadmConfigSpec), b.(*EtcdadmConfigSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigStatus)(nil), (*v1beta1.EtcdadmConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus(a.(*EtcdadmConfigStatus), b.(*v1beta1.EtcdadmConfigStatus), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigStatus)(nil), (*v1beta1.EtcdadmConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus(a.(*EtcdadmConfigStatus), b.(*v1beta1.EtcdadmConfigStatus), scope)
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigTemplate)(nil), (*v1beta1.EtcdadmConfigTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(a.(*EtcdadmConfigTemplate), b.(*v1beta1.EtcdadmConfigTemplate), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.EtcdadmConfigStatus)(nil), (*EtcdadmConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_EtcdadmConfigStatus_To_v1alpha3_EtcdadmConfigStatus(a.(*v1beta1.EtcdadmConfigStatus), b.(*EtcdadmConfigStatus), scope)
+	if err := s.AddGeneratedConversionFunc((*v1beta1.EtcdadmConfigTemplate)(nil), (*EtcdadmConfigTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigTemplate_To_v1alpha3_EtcdadmConfigTemplate(a.(*v1beta1.EtcdadmConfigTemplate), b.(*EtcdadmConfigTemplate), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ProxyConfiguration)(nil), (*v1beta1.ProxyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_ProxyConfiguration_To_v1beta1_ProxyConfiguration(a.(*ProxyConfiguration), b.(*v1beta1.ProxyConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigTemplateList)(nil), (*v1beta1.EtcdadmConfigTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(a.(*EtcdadmConfigTemplateList), b.(*v1beta1.EtcdadmConfigTemplateList), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.ProxyConfiguration)(nil), (*ProxyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration(a.(*v1beta1.ProxyConfiguration), b.(*ProxyConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*v1beta1.EtcdadmConfigTemplateList)(nil), (*EtcdadmConfigTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigTemplateList_To_v1alpha3_EtcdadmConfigTemplateList(a.(*v1beta1.EtcdadmConfigTemplateList), b.(*EtcdadmConfigTemplateList), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*RegistryMirrorConfiguration)(nil), (*v1beta1.RegistryMirrorConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration(a.(*RegistryMirrorConfiguration), b.(*v1beta1.RegistryMirrorConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigTemplateResource)(nil), (*v1beta1.EtcdadmConfigTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(a.(*EtcdadmConfigTemplateResource), b.(*v1beta1.EtcdadmConfigTemplateResource), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.RegistryMirrorConfiguration)(nil), (*RegistryMirrorConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration(a.(*v1beta1.RegistryMirrorConfiguration), b.(*RegistryMirrorConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigTemplateSpec)(nil), (*v1beta1.EtcdadmConfigTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(a.(*EtcdadmConfigTemplateSpec), b.(*v1beta1.EtcdadmConfigTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.EtcdadmConfigTemplateSpec)(nil), (*EtcdadmConfigTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigTemplateSpec_To_v1alpha3_EtcdadmConfigTemplateSpec(a.(*v1beta1.EtcdadmConfigTemplateSpec), b.(*EtcdadmConfigTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProxyConfiguration)(nil), (*v1beta1.ProxyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ProxyConfiguration_To_v1beta1_ProxyConfiguration(a.(*ProxyConfiguration), b.(*v1beta1.ProxyConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegistryMirrorConfiguration)(nil), (*v1beta1.RegistryMirrorConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration(a.(*RegistryMirrorConfiguration), b.(*v1beta1.RegistryMirrorConfiguration), scope)
 	}); err != nil {
 		return err
 	}
@@ -138,6 +148,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.CloudInitConfig)(nil), (*CloudInitConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig(a.(*v1beta1.CloudInitConfig), b.(*CloudInitConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.EtcdadmConfigSpec)(nil), (*EtcdadmConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec(a.(*v1beta1.EtcdadmConfigSpec), b.(*EtcdadmConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.EtcdadmConfigStatus)(nil), (*EtcdadmConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigStatus_To_v1alpha3_EtcdadmConfigStatus(a.(*v1beta1.EtcdadmConfigStatus), b.(*EtcdadmConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.EtcdadmConfigTemplateResource)(nil), (*EtcdadmConfigTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigTemplateResource_To_v1alpha3_EtcdadmConfigTemplateResource(a.(*v1beta1.EtcdadmConfigTemplateResource), b.(*EtcdadmConfigTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.ProxyConfiguration)(nil), (*ProxyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration(a.(*v1beta1.ProxyConfiguration), b.(*ProxyConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.RegistryMirrorConfiguration)(nil), (*RegistryMirrorConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration(a.(*v1beta1.RegistryMirrorConfiguration), b.(*RegistryMirrorConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -192,11 +232,13 @@ func autoConvert_v1beta1_BottlerocketConfig_To_v1alpha3_BottlerocketConfig(in *v
 	out.BootstrapImage = in.BootstrapImage
 	out.AdminImage = in.AdminImage
 	out.ControlImage = in.ControlImage
+	// WARNING: in.LearnerImage requires manual conversion: does not exist in peer-type
 	out.PauseImage = in.PauseImage
 	out.CustomHostContainers = *(*[]BottlerocketHostContainer)(unsafe.Pointer(&in.CustomHostContainers))
 	out.CustomBootstrapContainers = *(*[]BottlerocketBootstrapContainer)(unsafe.Pointer(&in.CustomBootstrapContainers))
 	out.Kernel = (*apiv1beta1.BottlerocketKernelSettings)(unsafe.Pointer(in.Kernel))
 	out.Boot = (*apiv1beta1.BottlerocketBootSettings)(unsafe.Pointer(in.Boot))
+	// WARNING: in.Settings requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -242,14 +284,10 @@ func autoConvert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig(in *v1beta1
 	out.Version = in.Version
 	out.EtcdReleaseURL = in.EtcdReleaseURL
 	out.InstallDir = in.InstallDir
+	// WARNING: in.ContainerdVersion requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig is an autogenerated conversion function.
-func Convert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig(in *v1beta1.CloudInitConfig, out *CloudInitConfig, s conversion.Scope) error {
-	return autoConvert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig(in, out, s)
-}
-
 func autoConvert_v1alpha3_EtcdadmConfig_To_v1beta1_EtcdadmConfig(in *EtcdadmConfig, out *v1beta1.EtcdadmConfig, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -340,10 +378,34 @@ func autoConvert_v1alpha3_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(in *Etc
 	} else {
 		out.BottlerocketConfig = nil
 	}
-	out.CloudInitConfig = (*v1beta1.CloudInitConfig)(unsafe.Pointer(in.CloudInitConfig))
+	if in.CloudInitConfig != nil {
+		in, out := &in.CloudInitConfig, &out.CloudInitConfig
+		*out = new(v1beta1.CloudInitConfig)
+		if err := Convert_v1alpha3_CloudInitConfig_To_v1beta1_CloudInitConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudInitConfig = nil
+	}
 	out.Files = *(*[]apiv1beta1.File)(unsafe.Pointer(&in.Files))
-	out.Proxy = (*v1beta1.ProxyConfiguration)(unsafe.Pointer(in.Proxy))
-	out.RegistryMirror = (*v1beta1.RegistryMirrorConfiguration)(unsafe.Pointer(in.RegistryMirror))
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(v1beta1.ProxyConfiguration)
+		if err := Convert_v1alpha3_ProxyConfiguration_To_v1beta1_ProxyConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Proxy = nil
+	}
+	if in.RegistryMirror != nil {
+		in, out := &in.RegistryMirror, &out.RegistryMirror
+		*out = new(v1beta1.RegistryMirrorConfiguration)
+		if err := Convert_v1alpha3_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RegistryMirror = nil
+	}
 	out.CipherSuites = in.CipherSuites
 	out.NTP = (*apiv1beta1.NTP)(unsafe.Pointer(in.NTP))
 	out.CertBundles = *(*[]apiv1beta1.CertBundle)(unsafe.Pointer(&in.CertBundles))
@@ -371,21 +433,46 @@ func autoConvert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec(in *v1b
 	} else {
 		out.BottlerocketConfig = nil
 	}
-	out.CloudInitConfig = (*CloudInitConfig)(unsafe.Pointer(in.CloudInitConfig))
+	if in.CloudInitConfig != nil {
+		in, out := &in.CloudInitConfig, &out.CloudInitConfig
+		*out = new(CloudInitConfig)
+		if err := Convert_v1beta1_CloudInitConfig_To_v1alpha3_CloudInitConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudInitConfig = nil
+	}
 	out.Files = *(*[]apiv1beta1.File)(unsafe.Pointer(&in.Files))
-	out.Proxy = (*ProxyConfiguration)(unsafe.Pointer(in.Proxy))
-	out.RegistryMirror = (*RegistryMirrorConfiguration)(unsafe.Pointer(in.RegistryMirror))
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfiguration)
+		if err := Convert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Proxy = nil
+	}
+	if in.RegistryMirror != nil {
+		in, out := &in.RegistryMirror, &out.RegistryMirror
+		*out = new(RegistryMirrorConfiguration)
+		if err := Convert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RegistryMirror = nil
+	}
 	out.CipherSuites = in.CipherSuites
 	out.NTP = (*apiv1beta1.NTP)(unsafe.Pointer(in.NTP))
 	out.CertBundles = *(*[]apiv1beta1.CertBundle)(unsafe.Pointer(&in.CertBundles))
+	// WARNING: in.Backup requires manual conversion: does not exist in peer-type
+	// WARNING: in.RestoreFrom requires manual conversion: does not exist in peer-type
+	// WARNING: in.JoinAsLearner requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIServerEtcdClientCertificate requires manual conversion: does not exist in peer-type
+	// WARNING: in.Network requires manual conversion: does not exist in peer-type
+	// WARNING: in.Kernel requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec is an autogenerated conversion function.
-func Convert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec(in *v1beta1.EtcdadmConfigSpec, out *EtcdadmConfigSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus(in *EtcdadmConfigStatus, out *v1beta1.EtcdadmConfigStatus, s conversion.Scope) error {
 	out.Conditions = *(*clusterapiapiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
 	out.DataSecretName = (*string)(unsafe.Pointer(in.DataSecretName))
@@ -402,12 +489,120 @@ func autoConvert_v1beta1_EtcdadmConfigStatus_To_v1alpha3_EtcdadmConfigStatus(in
 	out.Conditions = *(*apiv1alpha3.Conditions)(unsafe.Pointer(&in.Conditions))
 	out.DataSecretName = (*string)(unsafe.Pointer(in.DataSecretName))
 	out.Ready = in.Ready
+	// WARNING: in.V1Beta2 requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha3_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(in *EtcdadmConfigTemplate, out *v1beta1.EtcdadmConfigTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha3_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate is an autogenerated conversion function.
+func Convert_v1alpha3_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(in *EtcdadmConfigTemplate, out *v1beta1.EtcdadmConfigTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigTemplate_To_v1alpha3_EtcdadmConfigTemplate(in *v1beta1.EtcdadmConfigTemplate, out *EtcdadmConfigTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_EtcdadmConfigTemplateSpec_To_v1alpha3_EtcdadmConfigTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigTemplate_To_v1alpha3_EtcdadmConfigTemplate is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigTemplate_To_v1alpha3_EtcdadmConfigTemplate(in *v1beta1.EtcdadmConfigTemplate, out *EtcdadmConfigTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigTemplate_To_v1alpha3_EtcdadmConfigTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha3_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(in *EtcdadmConfigTemplateList, out *v1beta1.EtcdadmConfigTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.EtcdadmConfigTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList is an autogenerated conversion function.
+func Convert_v1alpha3_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(in *EtcdadmConfigTemplateList, out *v1beta1.EtcdadmConfigTemplateList, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigTemplateList_To_v1alpha3_EtcdadmConfigTemplateList(in *v1beta1.EtcdadmConfigTemplateList, out *EtcdadmConfigTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdadmConfigTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_EtcdadmConfigTemplate_To_v1alpha3_EtcdadmConfigTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigTemplateList_To_v1alpha3_EtcdadmConfigTemplateList is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigTemplateList_To_v1alpha3_EtcdadmConfigTemplateList(in *v1beta1.EtcdadmConfigTemplateList, out *EtcdadmConfigTemplateList, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigTemplateList_To_v1alpha3_EtcdadmConfigTemplateList(in, out, s)
+}
+
+func autoConvert_v1alpha3_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(in *EtcdadmConfigTemplateResource, out *v1beta1.EtcdadmConfigTemplateResource, s conversion.Scope) error {
+	if err := Convert_v1alpha3_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
 	return nil
 }
 
-// Convert_v1beta1_EtcdadmConfigStatus_To_v1alpha3_EtcdadmConfigStatus is an autogenerated conversion function.
-func Convert_v1beta1_EtcdadmConfigStatus_To_v1alpha3_EtcdadmConfigStatus(in *v1beta1.EtcdadmConfigStatus, out *EtcdadmConfigStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_EtcdadmConfigStatus_To_v1alpha3_EtcdadmConfigStatus(in, out, s)
+// Convert_v1alpha3_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource is an autogenerated conversion function.
+func Convert_v1alpha3_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(in *EtcdadmConfigTemplateResource, out *v1beta1.EtcdadmConfigTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigTemplateResource_To_v1alpha3_EtcdadmConfigTemplateResource(in *v1beta1.EtcdadmConfigTemplateResource, out *EtcdadmConfigTemplateResource, s conversion.Scope) error {
+	// WARNING: in.ObjectMeta requires manual conversion: does not exist in peer-type
+	if err := Convert_v1beta1_EtcdadmConfigSpec_To_v1alpha3_EtcdadmConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha3_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(in *EtcdadmConfigTemplateSpec, out *v1beta1.EtcdadmConfigTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha3_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec is an autogenerated conversion function.
+func Convert_v1alpha3_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(in *EtcdadmConfigTemplateSpec, out *v1beta1.EtcdadmConfigTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigTemplateSpec_To_v1alpha3_EtcdadmConfigTemplateSpec(in *v1beta1.EtcdadmConfigTemplateSpec, out *EtcdadmConfigTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_EtcdadmConfigTemplateResource_To_v1alpha3_EtcdadmConfigTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigTemplateSpec_To_v1alpha3_EtcdadmConfigTemplateSpec is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigTemplateSpec_To_v1alpha3_EtcdadmConfigTemplateSpec(in *v1beta1.EtcdadmConfigTemplateSpec, out *EtcdadmConfigTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigTemplateSpec_To_v1alpha3_EtcdadmConfigTemplateSpec(in, out, s)
 }
 
 func autoConvert_v1alpha3_ProxyConfiguration_To_v1beta1_ProxyConfiguration(in *ProxyConfiguration, out *v1beta1.ProxyConfiguration, s conversion.Scope) error {
@@ -426,14 +621,10 @@ func autoConvert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration(in *v
 	out.HTTPProxy = in.HTTPProxy
 	out.HTTPSProxy = in.HTTPSProxy
 	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	// WARNING: in.AutoNoProxy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration is an autogenerated conversion function.
-func Convert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration(in *v1beta1.ProxyConfiguration, out *ProxyConfiguration, s conversion.Scope) error {
-	return autoConvert_v1beta1_ProxyConfiguration_To_v1alpha3_ProxyConfiguration(in, out, s)
-}
-
 func autoConvert_v1alpha3_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration(in *RegistryMirrorConfiguration, out *v1beta1.RegistryMirrorConfiguration, s conversion.Scope) error {
 	out.Endpoint = in.Endpoint
 	out.CACert = in.CACert
@@ -448,10 +639,7 @@ func Convert_v1alpha3_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfi
 func autoConvert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration(in *v1beta1.RegistryMirrorConfiguration, out *RegistryMirrorConfiguration, s conversion.Scope) error {
 	out.Endpoint = in.Endpoint
 	out.CACert = in.CACert
+	// WARNING: in.Mirrors requires manual conversion: does not exist in peer-type
+	// WARNING: in.CredentialsRef requires manual conversion: does not exist in peer-type
 	return nil
 }
-
-// Convert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration is an autogenerated conversion function.
-func Convert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration(in *v1beta1.RegistryMirrorConfiguration, out *RegistryMirrorConfiguration, s conversion.Scope) error {
-	return autoConvert_v1beta1_RegistryMirrorConfiguration_To_v1alpha3_RegistryMirrorConfiguration(in, out, s)
-}
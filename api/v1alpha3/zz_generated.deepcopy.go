@@ -267,6 +267,96 @@ func (in *EtcdadmConfigStatus) DeepCopy() *EtcdadmConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigTemplate) DeepCopyInto(out *EtcdadmConfigTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigTemplate.
+func (in *EtcdadmConfigTemplate) DeepCopy() *EtcdadmConfigTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdadmConfigTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigTemplateList) DeepCopyInto(out *EtcdadmConfigTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdadmConfigTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigTemplateList.
+func (in *EtcdadmConfigTemplateList) DeepCopy() *EtcdadmConfigTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdadmConfigTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigTemplateResource) DeepCopyInto(out *EtcdadmConfigTemplateResource) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigTemplateResource.
+func (in *EtcdadmConfigTemplateResource) DeepCopy() *EtcdadmConfigTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigTemplateSpec) DeepCopyInto(out *EtcdadmConfigTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigTemplateSpec.
+func (in *EtcdadmConfigTemplateSpec) DeepCopy() *EtcdadmConfigTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyConfiguration) DeepCopyInto(out *ProxyConfiguration) {
 	*out = *in
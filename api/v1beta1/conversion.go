@@ -1,7 +1,106 @@
 package v1beta1
 
-// Hub marks EtcdadmConfig as a conversion hub.
-func (*EtcdadmConfig) Hub() {}
+import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
-// Hub marks EtcdadmConfigList as a conversion hub.
-func (*EtcdadmConfigList) Hub() {}
+	etcdv1beta2 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+)
+
+// ConvertTo converts this EtcdadmConfig to the Hub version (v1beta2).
+func (src *EtcdadmConfig) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*etcdv1beta2.EtcdadmConfig)
+	return Convert_v1beta1_EtcdadmConfig_To_v1beta2_EtcdadmConfig(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta2) to this EtcdadmConfig.
+func (dst *EtcdadmConfig) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*etcdv1beta2.EtcdadmConfig)
+	return Convert_v1beta2_EtcdadmConfig_To_v1beta1_EtcdadmConfig(src, dst, nil)
+}
+
+// ConvertTo converts this EtcdadmConfigList to the Hub version (v1beta2).
+func (src *EtcdadmConfigList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*etcdv1beta2.EtcdadmConfigList)
+	return Convert_v1beta1_EtcdadmConfigList_To_v1beta2_EtcdadmConfigList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta2) to this EtcdadmConfigList.
+func (dst *EtcdadmConfigList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*etcdv1beta2.EtcdadmConfigList)
+	return Convert_v1beta2_EtcdadmConfigList_To_v1beta1_EtcdadmConfigList(src, dst, nil)
+}
+
+// ConvertTo converts this EtcdadmConfigTemplate to the Hub version (v1beta2).
+func (src *EtcdadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*etcdv1beta2.EtcdadmConfigTemplate)
+	return Convert_v1beta1_EtcdadmConfigTemplate_To_v1beta2_EtcdadmConfigTemplate(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta2) to this EtcdadmConfigTemplate.
+func (dst *EtcdadmConfigTemplate) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*etcdv1beta2.EtcdadmConfigTemplate)
+	return Convert_v1beta2_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(src, dst, nil)
+}
+
+// ConvertTo converts this EtcdadmConfigTemplateList to the Hub version (v1beta2).
+func (src *EtcdadmConfigTemplateList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*etcdv1beta2.EtcdadmConfigTemplateList)
+	return Convert_v1beta1_EtcdadmConfigTemplateList_To_v1beta2_EtcdadmConfigTemplateList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta2) to this EtcdadmConfigTemplateList.
+func (dst *EtcdadmConfigTemplateList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*etcdv1beta2.EtcdadmConfigTemplateList)
+	return Convert_v1beta2_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(src, dst, nil)
+}
+
+// Convert_v1beta1_EtcdadmConfigStatus_To_v1beta2_EtcdadmConfigStatus moves the v1beta1 conditions to the deprecated
+// status and maps ready to the initialization status of the v1beta2 contract.
+func Convert_v1beta1_EtcdadmConfigStatus_To_v1beta2_EtcdadmConfigStatus(in *EtcdadmConfigStatus, out *etcdv1beta2.EtcdadmConfigStatus, s apiconversion.Scope) error {
+	if err := autoConvert_v1beta1_EtcdadmConfigStatus_To_v1beta2_EtcdadmConfigStatus(in, out, s); err != nil {
+		return err
+	}
+
+	out.Conditions = nil
+	if in.V1Beta2 != nil {
+		out.Conditions = in.V1Beta2.Conditions
+	}
+	out.DataSecretName = ptr.Deref(in.DataSecretName, "")
+	if in.Ready {
+		out.Initialization.DataSecretCreated = ptr.To(true)
+	}
+	if in.Conditions != nil {
+		out.Deprecated = &etcdv1beta2.EtcdadmConfigDeprecatedStatus{
+			V1Beta1: &etcdv1beta2.EtcdadmConfigV1Beta1DeprecatedStatus{},
+		}
+		clusterv1.Convert_v1beta1_Conditions_To_v1beta2_Deprecated_V1Beta1_Conditions(&in.Conditions, &out.Deprecated.V1Beta1.Conditions)
+	}
+	return nil
+}
+
+// Convert_v1beta2_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus restores the v1beta1 conditions from the
+// deprecated status and keeps the v1beta2 conditions in the v1beta2 status.
+func Convert_v1beta2_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus(in *etcdv1beta2.EtcdadmConfigStatus, out *EtcdadmConfigStatus, s apiconversion.Scope) error {
+	if err := autoConvert_v1beta2_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus(in, out, s); err != nil {
+		return err
+	}
+
+	out.Conditions = nil
+	if in.Deprecated != nil && in.Deprecated.V1Beta1 != nil {
+		if in.Deprecated.V1Beta1.Conditions != nil {
+			clusterv1.Convert_v1beta2_Deprecated_V1Beta1_Conditions_To_v1beta1_Conditions(&in.Deprecated.V1Beta1.Conditions, &out.Conditions)
+		}
+	}
+	out.DataSecretName = nil
+	if in.DataSecretName != "" {
+		out.DataSecretName = ptr.To(in.DataSecretName)
+	}
+	out.Ready = ptr.Deref(in.Initialization.DataSecretCreated, false)
+	if in.Conditions != nil {
+		out.V1Beta2 = &EtcdadmConfigV1Beta2Status{Conditions: in.Conditions}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+//go:build !race
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/randfill"
+
+	etcdv1beta2 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+)
+
+// Test is disabled when the race detector is enabled (via "//go:build !race" above) because otherwise the fuzz tests would just time out.
+
+func TestFuzzyConversion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := etcdv1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("for EtcdadmConfig", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme:      scheme,
+		Hub:         &etcdv1beta2.EtcdadmConfig{},
+		Spoke:       &EtcdadmConfig{},
+		FuzzerFuncs: []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+	t.Run("for EtcdadmConfigList", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme:                     scheme,
+		Hub:                        &etcdv1beta2.EtcdadmConfigList{},
+		Spoke:                      &EtcdadmConfigList{},
+		SkipSpokeAnnotationCleanup: true,
+		FuzzerFuncs:                []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+	t.Run("for EtcdadmConfigTemplate", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme:      scheme,
+		Hub:         &etcdv1beta2.EtcdadmConfigTemplate{},
+		Spoke:       &EtcdadmConfigTemplate{},
+		FuzzerFuncs: []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+}
+
+func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
+	return []interface{}{
+		hubEtcdadmConfigStatus,
+		spokeEtcdadmConfigStatus,
+		hubBottlerocketSettings,
+	}
+}
+
+// hubEtcdadmConfigStatus drops the status fields v1beta1 has no room for: the observed generation, a data secret
+// created set to false, which v1beta1 cannot tell apart from a missing one, and a deprecated status without conditions.
+func hubEtcdadmConfigStatus(in *etcdv1beta2.EtcdadmConfigStatus, c randfill.Continue) {
+	c.FillNoCustom(in)
+
+	in.ObservedGeneration = 0
+	if in.Initialization.DataSecretCreated != nil && !*in.Initialization.DataSecretCreated {
+		in.Initialization.DataSecretCreated = nil
+	}
+	if in.Deprecated != nil && (in.Deprecated.V1Beta1 == nil || in.Deprecated.V1Beta1.Conditions == nil) {
+		in.Deprecated = nil
+	}
+}
+
+// spokeEtcdadmConfigStatus drops an empty data secret name and a v1beta2 status without conditions, which the hub
+// cannot tell apart from missing ones.
+func spokeEtcdadmConfigStatus(in *EtcdadmConfigStatus, c randfill.Continue) {
+	c.FillNoCustom(in)
+
+	if in.DataSecretName != nil && *in.DataSecretName == "" {
+		in.DataSecretName = nil
+	}
+	if in.V1Beta2 != nil && in.V1Beta2.Conditions == nil {
+		in.V1Beta2 = nil
+	}
+}
+
+// hubBottlerocketSettings fills the Bottlerocket settings with a JSON object encoded the way the API server stores it.
+func hubBottlerocketSettings(in *apiextensionsv1.JSON, c randfill.Continue) {
+	in.Raw, _ = json.Marshal(map[string]interface{}{"kernel": map[string]string{"lockdown": c.String(0)}})
+}
@@ -0,0 +1,73 @@
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	etcdv1beta2 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+)
+
+func TestEtcdadmConfigStatusConversion(t *testing.T) {
+	readyCondition := metav1.Condition{
+		Type:   "Ready",
+		Status: metav1.ConditionTrue,
+		Reason: "Ready",
+	}
+	dataSecretCondition := clusterv1.Condition{
+		Type:   DataSecretAvailableCondition,
+		Status: "True",
+	}
+	tests := []struct {
+		name    string
+		v1beta1 EtcdadmConfigStatus
+		v1beta2 etcdv1beta2.EtcdadmConfigStatus
+	}{
+		{
+			name: "empty status",
+		},
+		{
+			name: "ready status",
+			v1beta1: EtcdadmConfigStatus{
+				Conditions:     clusterv1.Conditions{dataSecretCondition},
+				DataSecretName: ptr.To("etcd-0"),
+				Ready:          true,
+				V1Beta2: &EtcdadmConfigV1Beta2Status{
+					Conditions: []metav1.Condition{readyCondition},
+				},
+			},
+			v1beta2: etcdv1beta2.EtcdadmConfigStatus{
+				Conditions: []metav1.Condition{readyCondition},
+				Initialization: etcdv1beta2.EtcdadmConfigInitializationStatus{
+					DataSecretCreated: ptr.To(true),
+				},
+				DataSecretName: "etcd-0",
+				Deprecated: &etcdv1beta2.EtcdadmConfigDeprecatedStatus{
+					V1Beta1: &etcdv1beta2.EtcdadmConfigV1Beta1DeprecatedStatus{
+						Conditions: clusterv1beta2.Conditions{{
+							Type:   etcdv1beta2.DataSecretAvailableV1Beta1Condition,
+							Status: "True",
+						}},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hub := &etcdv1beta2.EtcdadmConfig{}
+			g.Expect((&EtcdadmConfig{Status: tt.v1beta1}).ConvertTo(hub)).To(Succeed())
+			g.Expect(hub.Status).To(Equal(tt.v1beta2))
+
+			spoke := &EtcdadmConfig{}
+			g.Expect(spoke.ConvertFrom(&etcdv1beta2.EtcdadmConfig{Status: tt.v1beta2})).To(Succeed())
+			g.Expect(spoke.Status).To(Equal(tt.v1beta1))
+		})
+	}
+}
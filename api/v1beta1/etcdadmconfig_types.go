@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
@@ -25,6 +27,16 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 const (
 	DataSecretAvailableCondition clusterv1.ConditionType = "DataSecretAvailable"
+	// EtcdMemberHealthyCondition reports whether the etcd member of the Machine serves linearizable reads without errors.
+	EtcdMemberHealthyCondition clusterv1.ConditionType = "EtcdMemberHealthy"
+	// EtcdMemberUnhealthyReason is used when the etcd member answers but cannot serve reads or reports errors such as alarms.
+	EtcdMemberUnhealthyReason = "EtcdMemberUnhealthy"
+	// EtcdMemberUnreachableReason is used when the etcd member cannot be reached through its client endpoint.
+	EtcdMemberUnreachableReason = "EtcdMemberUnreachable"
+	// WaitingForMachineAddressReason is used while the Machine has no address to reach its etcd member on.
+	WaitingForMachineAddressReason = "WaitingForMachineAddress"
+	// EtcdMemberFinalizer allows the controller to remove the etcd member of a Machine before the Machine goes away.
+	EtcdMemberFinalizer = "etcdadmconfig.bootstrap.cluster.x-k8s.io/etcd-member"
 	// CloudConfig make the bootstrap data to be of cloud-config format.
 	CloudConfig Format = "cloud-config"
 	// Bottlerocket make the bootstrap data to be of bottlerocket format.
@@ -32,7 +44,6 @@ const (
 )
 
 // Format specifies the output format of the bootstrap data
-// +kubebuilder:validation:Enum=cloud-config;bottlerocket
 type Format string
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -58,7 +69,8 @@ type EtcdadmConfigSpec struct {
 	// +optional
 	PostEtcdadmCommands []string `json:"postEtcdadmCommands,omitempty"`
 
-	// Format specifies the output format of the bootstrap data
+	// Format specifies the output format of the bootstrap data: cloud-config, the default, bottlerocket, or a format
+	// registered by a downstream build. The webhook rejects formats no renderer is registered for.
 	// +optional
 	Format Format `json:"format,omitempty"`
 
@@ -74,8 +86,8 @@ type EtcdadmConfigSpec struct {
 	// +optional
 	Files []capbk.File `json:"files,omitempty"`
 
-	// Proxy holds the https and no proxy information
-	// This is only used for bottlerocket
+	// Proxy holds the http, https and no proxy information. With cloud-config it is also exported to etcdadm and the
+	// commands that install it.
 	// +optional
 	Proxy *ProxyConfiguration `json:"proxy,omitempty"`
 
@@ -93,9 +105,40 @@ type EtcdadmConfigSpec struct {
 	// +optional
 	NTP *capbk.NTP `json:"ntp,omitempty"`
 
-	// Certbundle holds additional cert bundles.
+	// CertBundles holds additional CA certificates the machines trust: as settings.pki entries on Bottlerocket, and
+	// added to the trust store of the distro before any other command runs with cloud-config.
 	// +optional
 	CertBundles []capbk.CertBundle `json:"certBundles,omitempty"`
+
+	// Backup holds the settings for scheduled etcd snapshot backups
+	// +optional
+	Backup *BackupConfiguration `json:"backup,omitempty"`
+
+	// RestoreFrom initializes the etcd cluster from an existing snapshot instead of starting it empty.
+	// It is only used by the machine initializing the cluster, members joining afterwards are unaffected.
+	// This is only used for cloud-config
+	// +optional
+	RestoreFrom *RestoreConfiguration `json:"restoreFrom,omitempty"`
+
+	// JoinAsLearner makes joining members enter the cluster as non-voting learners. A learner is promoted
+	// to a voting member once it has caught up with the leader, and the bootstrap is only reported
+	// successful after the promotion, except with bottlerocket, where the learner is promoted by a host container
+	// running alongside the bootstrap and bottlerocketConfig.learnerImage is required.
+	// +optional
+	JoinAsLearner bool `json:"joinAsLearner,omitempty"`
+
+	// APIServerEtcdClientCertificate makes the controller issue and renew the client certificate the kube-apiserver
+	// uses to connect to etcd, signed by the etcd CA of the cluster.
+	// +optional
+	APIServerEtcdClientCertificate *APIServerEtcdClientCertificate `json:"apiServerEtcdClientCertificate,omitempty"`
+
+	// Network holds the DNS and static host settings of etcd machines.
+	// +optional
+	Network *NetworkConfiguration `json:"network,omitempty"`
+
+	// Kernel holds the sysctl settings and boot parameters of the kernel of etcd machines.
+	// +optional
+	Kernel *KernelConfiguration `json:"kernel,omitempty"`
 }
 
 type BottlerocketConfig struct {
@@ -113,6 +156,12 @@ type BottlerocketConfig struct {
 	// +optional
 	ControlImage string `json:"controlImage,omitempty"`
 
+	// LearnerImage specifies the image of the etcd-learner containers, required to join members as learners.
+	// A bootstrap container adds the member as a learner before it joins, and a host container promotes it once it
+	// has caught up with the leader. Both run the bash script in their user data, and need curl, openssl and ip.
+	// +optional
+	LearnerImage string `json:"learnerImage,omitempty"`
+
 	// PauseImage specifies the image to use for the pause container
 	PauseImage string `json:"pauseImage"`
 
@@ -129,6 +178,16 @@ type BottlerocketConfig struct {
 
 	// Boot specifies boot settings for bottlerocket
 	Boot *capbk.BottlerocketBootSettings `json:"boot,omitempty"`
+
+	// Settings are Bottlerocket settings, keyed as under `settings` in the Bottlerocket user data, that are merged
+	// over the settings generated from the other fields. Tables are merged key by key, any other value replaces the
+	// generated one. The bootstrap and backup host containers and the hostname are owned by the provider and may not
+	// be set.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Settings *apiextensionsv1.JSON `json:"settings,omitempty"`
 }
 
 // BottlerocketHostContainer holds the host container setting for bottlerocket.
@@ -184,11 +243,19 @@ type CloudInitConfig struct {
 	// InstallDir is an optional field to specify where etcdadm will extract etcd binaries to
 	// +optional
 	InstallDir string `json:"installDir,omitempty"`
+
+	// ContainerdVersion is the version of containerd in the machine image, such as 1.7.20 or 2.0.0. Containerd 1.x
+	// needs its registry config_path set to read the hosts.toml files registry mirrors are written to, while 2.x
+	// reads them by default. When empty, the version is read from containerd on the machine.
+	// +kubebuilder:validation:Pattern=`^v?[0-9]+(\.[0-9]+){0,2}$`
+	// +optional
+	ContainerdVersion string `json:"containerdVersion,omitempty"`
 }
 
 // ProxyConfiguration holds the settings for proxying bottlerocket services
 type ProxyConfiguration struct {
 	// HTTP Proxy
+	// Bottlerocket has a single proxy setting, so it only uses HTTPProxy when HTTPSProxy is not set.
 	HTTPProxy string `json:"httpProxy,omitempty"`
 
 	// HTTPS proxy
@@ -196,15 +263,218 @@ type ProxyConfiguration struct {
 
 	// No proxy, list of ips that should not use proxy
 	NoProxy []string `json:"noProxy,omitempty"`
+
+	// AutoNoProxy adds the pod and service CIDRs and the control plane endpoint of the Cluster, the address of the etcd
+	// member joined and the addresses of the etcd Machines to NoProxy when the bootstrap data is generated.
+	// +optional
+	AutoNoProxy bool `json:"autoNoProxy,omitempty"`
 }
 
 // RegistryMirrorConfiguration holds the settings for image registry mirror
 type RegistryMirrorConfiguration struct {
 	// Endpoint defines the registry mirror endpoint to use for pulling images
+	// It mirrors public.ecr.aws.
 	Endpoint string `json:"endpoint,omitempty"`
 
 	// CACert defines the CA cert for the registry mirror
 	CACert string `json:"caCert,omitempty"`
+
+	// Mirrors are the mirrors of other upstream registries.
+	// +optional
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+
+	// CredentialsRef references the Secret holding the credentials of the mirrors. When it is set, the bootstrap data
+	// is not generated until the Secret can be read. Otherwise, the credentials are read from the
+	// "registry-credentials" Secret, if it exists.
+	// +optional
+	CredentialsRef *RegistryCredentialsReference `json:"credentialsRef,omitempty"`
+}
+
+// RegistryCredentialsReference references a Secret in the EtcdadmConfig namespace holding registry credentials:
+// either a kubernetes.io/dockerconfigjson Secret, with credentials for each registry, or a Secret holding a username
+// and a password used for every mirror.
+type RegistryCredentialsReference struct {
+	// Name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// UsernameKey is the key holding the username. Defaults to "username".
+	// Ignored for kubernetes.io/dockerconfigjson Secrets.
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key holding the password. Defaults to "password".
+	// Ignored for kubernetes.io/dockerconfigjson Secrets.
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// RegistryMirror maps an upstream registry to the mirror its images are pulled from.
+type RegistryMirror struct {
+	// Upstream is the host of the registry mirrored, such as docker.io, registry.k8s.io or quay.io.
+	// +kubebuilder:validation:MinLength=1
+	Upstream string `json:"upstream"`
+
+	// Endpoint is the host, and optionally the port, of the mirror.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// PathPrefix is the path the images of the upstream registry are served under on the mirror, such as the
+	// project of a Harbor registry.
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// CACert is the PEM encoded CA certificate of the mirror.
+	// +optional
+	CACert string `json:"caCert,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of the mirror.
+	// This is not supported with bottlerocket.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// NetworkConfiguration holds the network settings of etcd machines on networks without DHCP provided DNS.
+// On bottlerocket they are rendered into the network and dns settings, on cloud-config into resolv_conf and
+// /etc/hosts.
+type NetworkConfiguration struct {
+	// NameServers are the IP addresses of the DNS servers the machine resolves names with.
+	// +optional
+	NameServers []string `json:"nameServers,omitempty"`
+
+	// SearchDomains are the domains searched when resolving names that are not fully qualified.
+	// +optional
+	SearchDomains []string `json:"searchDomains,omitempty"`
+
+	// Hosts are static entries added to /etc/hosts.
+	// +optional
+	Hosts []HostEntry `json:"hosts,omitempty"`
+}
+
+// KernelConfiguration holds the kernel settings of etcd machines. On bottlerocket they are rendered into the kernel and
+// boot settings, where BottlerocketConfig.Kernel and BottlerocketConfig.Boot take precedence over them. On cloud-config
+// the sysctl settings are written to /etc/sysctl.d and applied before etcdadm runs, and the boot parameters are added
+// to the kernel command line of the bootloader, after which the machine reboots once before anything else runs.
+type KernelConfiguration struct {
+	// SysctlSettings are the sysctl settings of the kernel, keyed by name.
+	// +optional
+	SysctlSettings map[string]string `json:"sysctlSettings,omitempty"`
+
+	// BootKernelParameters are the parameters of the kernel command line, keyed by name. A parameter is added once
+	// for each of its values as name=value, or as name alone when it has no value.
+	// +optional
+	BootKernelParameters map[string][]string `json:"bootKernelParameters,omitempty"`
+}
+
+// HostEntry maps an IP address to host names.
+type HostEntry struct {
+	// IP is the address the host names resolve to.
+	IP string `json:"ip"`
+
+	// Hostnames are the names resolving to IP.
+	// +kubebuilder:validation:MinItems=1
+	Hostnames []string `json:"hostnames"`
+}
+
+// BackupConfiguration holds the settings for scheduled etcd snapshot backups.
+// On cloud-config a systemd timer runs `etcdctl snapshot save`, on bottlerocket a host container does.
+type BackupConfiguration struct {
+	// Schedule is a systemd calendar expression defining when snapshots are taken.
+	// Defaults to "hourly".
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Retention is the number of snapshots to keep in each destination.
+	// Older snapshots are removed after every successful backup. Zero keeps all snapshots.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retention int32 `json:"retention,omitempty"`
+
+	// LocalPath is the directory on the etcd node snapshots are written to.
+	// Defaults to "/var/lib/etcd-backup".
+	// +optional
+	LocalPath string `json:"localPath,omitempty"`
+
+	// S3 uploads every snapshot to an S3-compatible object store.
+	// +optional
+	S3 *S3BackupConfiguration `json:"s3,omitempty"`
+
+	// Image is the host container image running the backups.
+	// The container receives the backup settings as an environment file in its user data.
+	// This is only used for bottlerocket
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// S3BackupConfiguration holds the settings for uploading etcd snapshots to an S3-compatible endpoint
+type S3BackupConfiguration struct {
+	// Endpoint is the URL of the S3-compatible service, for example https://s3.us-west-2.amazonaws.com or http://minio:9000.
+	// Objects are addressed path-style as <endpoint>/<bucket>/<prefix><snapshot>.
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the bucket snapshots are uploaded to.
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the snapshot object names.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region used to sign requests. Defaults to "us-east-1".
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecretRef references a Secret in the EtcdadmConfig namespace
+	// holding the "accessKeyID" and "secretAccessKey" keys.
+	// The credentials are written in plaintext into the bootstrap data of the machines.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// RestoreConfiguration holds the source of the snapshot a new etcd cluster is restored from.
+// Exactly one of URL, SecretRef and ConfigMapRef must be set.
+type RestoreConfiguration struct {
+	// URL is the location the snapshot is downloaded from.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum the snapshot is verified against before it is restored.
+	// Required when URL is set.
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+
+	// SecretRef references a key of a Secret in the EtcdadmConfig namespace holding the snapshot.
+	// +optional
+	SecretRef *SnapshotSourceReference `json:"secretRef,omitempty"`
+
+	// ConfigMapRef references a binaryData key of a ConfigMap in the EtcdadmConfig namespace holding the snapshot.
+	// +optional
+	ConfigMapRef *SnapshotSourceReference `json:"configMapRef,omitempty"`
+}
+
+// SnapshotSourceReference references a key of a Secret or ConfigMap holding an etcd snapshot
+type SnapshotSourceReference struct {
+	// Name of the Secret or ConfigMap.
+	Name string `json:"name"`
+
+	// Key holding the snapshot. Defaults to "snapshot.db".
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// APIServerEtcdClientCertificate configures the kube-apiserver etcd client certificate of a cluster.
+// The certificate is stored in the tls.crt and tls.key keys of a Secret owned by the Cluster and
+// renewed once less than a third of its validity is left.
+type APIServerEtcdClientCertificate struct {
+	// SecretName is the name of the Secret holding the certificate. Defaults to "<cluster name>-apiserver-etcd-client".
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// CommonName of the certificate. Defaults to "kube-apiserver-etcd-client".
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// Validity of the certificate. Defaults to one year.
+	// +optional
+	Validity *metav1.Duration `json:"validity,omitempty"`
 }
 
 // EtcdadmConfigStatus defines the observed state of EtcdadmConfig
@@ -216,10 +486,23 @@ type EtcdadmConfigStatus struct {
 	DataSecretName *string `json:"dataSecretName,omitempty"`
 
 	Ready bool `json:"ready,omitempty"`
+
+	// V1Beta2 groups all the fields that will be added or modified in EtcdadmConfig's status with the v1beta2 version.
+	// +optional
+	V1Beta2 *EtcdadmConfigV1Beta2Status `json:"v1beta2,omitempty"`
+}
+
+// EtcdadmConfigV1Beta2Status groups all the fields that will be added or modified in EtcdadmConfig with the v1beta2 version.
+type EtcdadmConfigV1Beta2Status struct {
+	// Conditions represents the observations of an EtcdadmConfig's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
-// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // EtcdadmConfig is the Schema for the etcdadmconfigs API
 type EtcdadmConfig struct {
@@ -0,0 +1,60 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+)
+
+// EtcdadmConfigTemplateSpec defines the desired state of EtcdadmConfigTemplate
+type EtcdadmConfigTemplateSpec struct {
+	Template EtcdadmConfigTemplateResource `json:"template"`
+}
+
+// EtcdadmConfigTemplateResource defines the Template structure
+type EtcdadmConfigTemplateResource struct {
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	ObjectMeta clusterv1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EtcdadmConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=etcdadmconfigtemplates,scope=Namespaced,categories=cluster-api
+// EtcdadmConfigTemplate is the Schema for the etcdadmconfigtemplates API
+type EtcdadmConfigTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EtcdadmConfigTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdadmConfigTemplateList contains a list of EtcdadmConfigTemplate
+type EtcdadmConfigTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdadmConfigTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdadmConfigTemplate{}, &EtcdadmConfigTemplateList{})
+}
@@ -0,0 +1,998 @@
+//go:build !ignore_autogenerated_etcd_bootstrap
+// +build !ignore_autogenerated_etcd_bootstrap
+
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	unsafe "unsafe"
+
+	v1beta2 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+	clusterapiapiv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*APIServerEtcdClientCertificate)(nil), (*v1beta2.APIServerEtcdClientCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_APIServerEtcdClientCertificate_To_v1beta2_APIServerEtcdClientCertificate(a.(*APIServerEtcdClientCertificate), b.(*v1beta2.APIServerEtcdClientCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.APIServerEtcdClientCertificate)(nil), (*APIServerEtcdClientCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_APIServerEtcdClientCertificate_To_v1beta1_APIServerEtcdClientCertificate(a.(*v1beta2.APIServerEtcdClientCertificate), b.(*APIServerEtcdClientCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BackupConfiguration)(nil), (*v1beta2.BackupConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BackupConfiguration_To_v1beta2_BackupConfiguration(a.(*BackupConfiguration), b.(*v1beta2.BackupConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.BackupConfiguration)(nil), (*BackupConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_BackupConfiguration_To_v1beta1_BackupConfiguration(a.(*v1beta2.BackupConfiguration), b.(*BackupConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BottlerocketBootstrapContainer)(nil), (*v1beta2.BottlerocketBootstrapContainer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BottlerocketBootstrapContainer_To_v1beta2_BottlerocketBootstrapContainer(a.(*BottlerocketBootstrapContainer), b.(*v1beta2.BottlerocketBootstrapContainer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.BottlerocketBootstrapContainer)(nil), (*BottlerocketBootstrapContainer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_BottlerocketBootstrapContainer_To_v1beta1_BottlerocketBootstrapContainer(a.(*v1beta2.BottlerocketBootstrapContainer), b.(*BottlerocketBootstrapContainer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BottlerocketConfig)(nil), (*v1beta2.BottlerocketConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BottlerocketConfig_To_v1beta2_BottlerocketConfig(a.(*BottlerocketConfig), b.(*v1beta2.BottlerocketConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.BottlerocketConfig)(nil), (*BottlerocketConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_BottlerocketConfig_To_v1beta1_BottlerocketConfig(a.(*v1beta2.BottlerocketConfig), b.(*BottlerocketConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BottlerocketHostContainer)(nil), (*v1beta2.BottlerocketHostContainer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BottlerocketHostContainer_To_v1beta2_BottlerocketHostContainer(a.(*BottlerocketHostContainer), b.(*v1beta2.BottlerocketHostContainer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.BottlerocketHostContainer)(nil), (*BottlerocketHostContainer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_BottlerocketHostContainer_To_v1beta1_BottlerocketHostContainer(a.(*v1beta2.BottlerocketHostContainer), b.(*BottlerocketHostContainer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudInitConfig)(nil), (*v1beta2.CloudInitConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CloudInitConfig_To_v1beta2_CloudInitConfig(a.(*CloudInitConfig), b.(*v1beta2.CloudInitConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.CloudInitConfig)(nil), (*CloudInitConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CloudInitConfig_To_v1beta1_CloudInitConfig(a.(*v1beta2.CloudInitConfig), b.(*CloudInitConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfig)(nil), (*v1beta2.EtcdadmConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfig_To_v1beta2_EtcdadmConfig(a.(*EtcdadmConfig), b.(*v1beta2.EtcdadmConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.EtcdadmConfig)(nil), (*EtcdadmConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdadmConfig_To_v1beta1_EtcdadmConfig(a.(*v1beta2.EtcdadmConfig), b.(*EtcdadmConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigList)(nil), (*v1beta2.EtcdadmConfigList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigList_To_v1beta2_EtcdadmConfigList(a.(*EtcdadmConfigList), b.(*v1beta2.EtcdadmConfigList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.EtcdadmConfigList)(nil), (*EtcdadmConfigList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdadmConfigList_To_v1beta1_EtcdadmConfigList(a.(*v1beta2.EtcdadmConfigList), b.(*EtcdadmConfigList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigSpec)(nil), (*v1beta2.EtcdadmConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigSpec_To_v1beta2_EtcdadmConfigSpec(a.(*EtcdadmConfigSpec), b.(*v1beta2.EtcdadmConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.EtcdadmConfigSpec)(nil), (*EtcdadmConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(a.(*v1beta2.EtcdadmConfigSpec), b.(*EtcdadmConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigTemplate)(nil), (*v1beta2.EtcdadmConfigTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigTemplate_To_v1beta2_EtcdadmConfigTemplate(a.(*EtcdadmConfigTemplate), b.(*v1beta2.EtcdadmConfigTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.EtcdadmConfigTemplate)(nil), (*EtcdadmConfigTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(a.(*v1beta2.EtcdadmConfigTemplate), b.(*EtcdadmConfigTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigTemplateList)(nil), (*v1beta2.EtcdadmConfigTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigTemplateList_To_v1beta2_EtcdadmConfigTemplateList(a.(*EtcdadmConfigTemplateList), b.(*v1beta2.EtcdadmConfigTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.EtcdadmConfigTemplateList)(nil), (*EtcdadmConfigTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(a.(*v1beta2.EtcdadmConfigTemplateList), b.(*EtcdadmConfigTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigTemplateResource)(nil), (*v1beta2.EtcdadmConfigTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigTemplateResource_To_v1beta2_EtcdadmConfigTemplateResource(a.(*EtcdadmConfigTemplateResource), b.(*v1beta2.EtcdadmConfigTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.EtcdadmConfigTemplateResource)(nil), (*EtcdadmConfigTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(a.(*v1beta2.EtcdadmConfigTemplateResource), b.(*EtcdadmConfigTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdadmConfigTemplateSpec)(nil), (*v1beta2.EtcdadmConfigTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigTemplateSpec_To_v1beta2_EtcdadmConfigTemplateSpec(a.(*EtcdadmConfigTemplateSpec), b.(*v1beta2.EtcdadmConfigTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.EtcdadmConfigTemplateSpec)(nil), (*EtcdadmConfigTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(a.(*v1beta2.EtcdadmConfigTemplateSpec), b.(*EtcdadmConfigTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HostEntry)(nil), (*v1beta2.HostEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HostEntry_To_v1beta2_HostEntry(a.(*HostEntry), b.(*v1beta2.HostEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.HostEntry)(nil), (*HostEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_HostEntry_To_v1beta1_HostEntry(a.(*v1beta2.HostEntry), b.(*HostEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KernelConfiguration)(nil), (*v1beta2.KernelConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KernelConfiguration_To_v1beta2_KernelConfiguration(a.(*KernelConfiguration), b.(*v1beta2.KernelConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.KernelConfiguration)(nil), (*KernelConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_KernelConfiguration_To_v1beta1_KernelConfiguration(a.(*v1beta2.KernelConfiguration), b.(*KernelConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkConfiguration)(nil), (*v1beta2.NetworkConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkConfiguration_To_v1beta2_NetworkConfiguration(a.(*NetworkConfiguration), b.(*v1beta2.NetworkConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.NetworkConfiguration)(nil), (*NetworkConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_NetworkConfiguration_To_v1beta1_NetworkConfiguration(a.(*v1beta2.NetworkConfiguration), b.(*NetworkConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProxyConfiguration)(nil), (*v1beta2.ProxyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ProxyConfiguration_To_v1beta2_ProxyConfiguration(a.(*ProxyConfiguration), b.(*v1beta2.ProxyConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.ProxyConfiguration)(nil), (*ProxyConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ProxyConfiguration_To_v1beta1_ProxyConfiguration(a.(*v1beta2.ProxyConfiguration), b.(*ProxyConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegistryCredentialsReference)(nil), (*v1beta2.RegistryCredentialsReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RegistryCredentialsReference_To_v1beta2_RegistryCredentialsReference(a.(*RegistryCredentialsReference), b.(*v1beta2.RegistryCredentialsReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.RegistryCredentialsReference)(nil), (*RegistryCredentialsReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_RegistryCredentialsReference_To_v1beta1_RegistryCredentialsReference(a.(*v1beta2.RegistryCredentialsReference), b.(*RegistryCredentialsReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegistryMirror)(nil), (*v1beta2.RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RegistryMirror_To_v1beta2_RegistryMirror(a.(*RegistryMirror), b.(*v1beta2.RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.RegistryMirror)(nil), (*RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_RegistryMirror_To_v1beta1_RegistryMirror(a.(*v1beta2.RegistryMirror), b.(*RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegistryMirrorConfiguration)(nil), (*v1beta2.RegistryMirrorConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RegistryMirrorConfiguration_To_v1beta2_RegistryMirrorConfiguration(a.(*RegistryMirrorConfiguration), b.(*v1beta2.RegistryMirrorConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.RegistryMirrorConfiguration)(nil), (*RegistryMirrorConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration(a.(*v1beta2.RegistryMirrorConfiguration), b.(*RegistryMirrorConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RestoreConfiguration)(nil), (*v1beta2.RestoreConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RestoreConfiguration_To_v1beta2_RestoreConfiguration(a.(*RestoreConfiguration), b.(*v1beta2.RestoreConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.RestoreConfiguration)(nil), (*RestoreConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_RestoreConfiguration_To_v1beta1_RestoreConfiguration(a.(*v1beta2.RestoreConfiguration), b.(*RestoreConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*S3BackupConfiguration)(nil), (*v1beta2.S3BackupConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_S3BackupConfiguration_To_v1beta2_S3BackupConfiguration(a.(*S3BackupConfiguration), b.(*v1beta2.S3BackupConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.S3BackupConfiguration)(nil), (*S3BackupConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_S3BackupConfiguration_To_v1beta1_S3BackupConfiguration(a.(*v1beta2.S3BackupConfiguration), b.(*S3BackupConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotSourceReference)(nil), (*v1beta2.SnapshotSourceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SnapshotSourceReference_To_v1beta2_SnapshotSourceReference(a.(*SnapshotSourceReference), b.(*v1beta2.SnapshotSourceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.SnapshotSourceReference)(nil), (*SnapshotSourceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_SnapshotSourceReference_To_v1beta1_SnapshotSourceReference(a.(*v1beta2.SnapshotSourceReference), b.(*SnapshotSourceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*EtcdadmConfigStatus)(nil), (*v1beta2.EtcdadmConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdadmConfigStatus_To_v1beta2_EtcdadmConfigStatus(a.(*EtcdadmConfigStatus), b.(*v1beta2.EtcdadmConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta2.EtcdadmConfigStatus)(nil), (*EtcdadmConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus(a.(*v1beta2.EtcdadmConfigStatus), b.(*EtcdadmConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1beta1_APIServerEtcdClientCertificate_To_v1beta2_APIServerEtcdClientCertificate(in *APIServerEtcdClientCertificate, out *v1beta2.APIServerEtcdClientCertificate, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.CommonName = in.CommonName
+	out.Validity = (*v1.Duration)(unsafe.Pointer(in.Validity))
+	return nil
+}
+
+// Convert_v1beta1_APIServerEtcdClientCertificate_To_v1beta2_APIServerEtcdClientCertificate is an autogenerated conversion function.
+func Convert_v1beta1_APIServerEtcdClientCertificate_To_v1beta2_APIServerEtcdClientCertificate(in *APIServerEtcdClientCertificate, out *v1beta2.APIServerEtcdClientCertificate, s conversion.Scope) error {
+	return autoConvert_v1beta1_APIServerEtcdClientCertificate_To_v1beta2_APIServerEtcdClientCertificate(in, out, s)
+}
+
+func autoConvert_v1beta2_APIServerEtcdClientCertificate_To_v1beta1_APIServerEtcdClientCertificate(in *v1beta2.APIServerEtcdClientCertificate, out *APIServerEtcdClientCertificate, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.CommonName = in.CommonName
+	out.Validity = (*v1.Duration)(unsafe.Pointer(in.Validity))
+	return nil
+}
+
+// Convert_v1beta2_APIServerEtcdClientCertificate_To_v1beta1_APIServerEtcdClientCertificate is an autogenerated conversion function.
+func Convert_v1beta2_APIServerEtcdClientCertificate_To_v1beta1_APIServerEtcdClientCertificate(in *v1beta2.APIServerEtcdClientCertificate, out *APIServerEtcdClientCertificate, s conversion.Scope) error {
+	return autoConvert_v1beta2_APIServerEtcdClientCertificate_To_v1beta1_APIServerEtcdClientCertificate(in, out, s)
+}
+
+func autoConvert_v1beta1_BackupConfiguration_To_v1beta2_BackupConfiguration(in *BackupConfiguration, out *v1beta2.BackupConfiguration, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Retention = in.Retention
+	out.LocalPath = in.LocalPath
+	out.S3 = (*v1beta2.S3BackupConfiguration)(unsafe.Pointer(in.S3))
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_v1beta1_BackupConfiguration_To_v1beta2_BackupConfiguration is an autogenerated conversion function.
+func Convert_v1beta1_BackupConfiguration_To_v1beta2_BackupConfiguration(in *BackupConfiguration, out *v1beta2.BackupConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta1_BackupConfiguration_To_v1beta2_BackupConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta2_BackupConfiguration_To_v1beta1_BackupConfiguration(in *v1beta2.BackupConfiguration, out *BackupConfiguration, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Retention = in.Retention
+	out.LocalPath = in.LocalPath
+	out.S3 = (*S3BackupConfiguration)(unsafe.Pointer(in.S3))
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_v1beta2_BackupConfiguration_To_v1beta1_BackupConfiguration is an autogenerated conversion function.
+func Convert_v1beta2_BackupConfiguration_To_v1beta1_BackupConfiguration(in *v1beta2.BackupConfiguration, out *BackupConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta2_BackupConfiguration_To_v1beta1_BackupConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta1_BottlerocketBootstrapContainer_To_v1beta2_BottlerocketBootstrapContainer(in *BottlerocketBootstrapContainer, out *v1beta2.BottlerocketBootstrapContainer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	out.Essential = in.Essential
+	out.Mode = in.Mode
+	out.UserData = in.UserData
+	return nil
+}
+
+// Convert_v1beta1_BottlerocketBootstrapContainer_To_v1beta2_BottlerocketBootstrapContainer is an autogenerated conversion function.
+func Convert_v1beta1_BottlerocketBootstrapContainer_To_v1beta2_BottlerocketBootstrapContainer(in *BottlerocketBootstrapContainer, out *v1beta2.BottlerocketBootstrapContainer, s conversion.Scope) error {
+	return autoConvert_v1beta1_BottlerocketBootstrapContainer_To_v1beta2_BottlerocketBootstrapContainer(in, out, s)
+}
+
+func autoConvert_v1beta2_BottlerocketBootstrapContainer_To_v1beta1_BottlerocketBootstrapContainer(in *v1beta2.BottlerocketBootstrapContainer, out *BottlerocketBootstrapContainer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	out.Essential = in.Essential
+	out.Mode = in.Mode
+	out.UserData = in.UserData
+	return nil
+}
+
+// Convert_v1beta2_BottlerocketBootstrapContainer_To_v1beta1_BottlerocketBootstrapContainer is an autogenerated conversion function.
+func Convert_v1beta2_BottlerocketBootstrapContainer_To_v1beta1_BottlerocketBootstrapContainer(in *v1beta2.BottlerocketBootstrapContainer, out *BottlerocketBootstrapContainer, s conversion.Scope) error {
+	return autoConvert_v1beta2_BottlerocketBootstrapContainer_To_v1beta1_BottlerocketBootstrapContainer(in, out, s)
+}
+
+func autoConvert_v1beta1_BottlerocketConfig_To_v1beta2_BottlerocketConfig(in *BottlerocketConfig, out *v1beta2.BottlerocketConfig, s conversion.Scope) error {
+	out.EtcdImage = in.EtcdImage
+	out.BootstrapImage = in.BootstrapImage
+	out.AdminImage = in.AdminImage
+	out.ControlImage = in.ControlImage
+	out.LearnerImage = in.LearnerImage
+	out.PauseImage = in.PauseImage
+	out.CustomHostContainers = *(*[]v1beta2.BottlerocketHostContainer)(unsafe.Pointer(&in.CustomHostContainers))
+	out.CustomBootstrapContainers = *(*[]v1beta2.BottlerocketBootstrapContainer)(unsafe.Pointer(&in.CustomBootstrapContainers))
+	out.Kernel = (*apiv1beta1.BottlerocketKernelSettings)(unsafe.Pointer(in.Kernel))
+	out.Boot = (*apiv1beta1.BottlerocketBootSettings)(unsafe.Pointer(in.Boot))
+	out.Settings = (*apiextensionsv1.JSON)(unsafe.Pointer(in.Settings))
+	return nil
+}
+
+// Convert_v1beta1_BottlerocketConfig_To_v1beta2_BottlerocketConfig is an autogenerated conversion function.
+func Convert_v1beta1_BottlerocketConfig_To_v1beta2_BottlerocketConfig(in *BottlerocketConfig, out *v1beta2.BottlerocketConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_BottlerocketConfig_To_v1beta2_BottlerocketConfig(in, out, s)
+}
+
+func autoConvert_v1beta2_BottlerocketConfig_To_v1beta1_BottlerocketConfig(in *v1beta2.BottlerocketConfig, out *BottlerocketConfig, s conversion.Scope) error {
+	out.EtcdImage = in.EtcdImage
+	out.BootstrapImage = in.BootstrapImage
+	out.AdminImage = in.AdminImage
+	out.ControlImage = in.ControlImage
+	out.LearnerImage = in.LearnerImage
+	out.PauseImage = in.PauseImage
+	out.CustomHostContainers = *(*[]BottlerocketHostContainer)(unsafe.Pointer(&in.CustomHostContainers))
+	out.CustomBootstrapContainers = *(*[]BottlerocketBootstrapContainer)(unsafe.Pointer(&in.CustomBootstrapContainers))
+	out.Kernel = (*apiv1beta1.BottlerocketKernelSettings)(unsafe.Pointer(in.Kernel))
+	out.Boot = (*apiv1beta1.BottlerocketBootSettings)(unsafe.Pointer(in.Boot))
+	out.Settings = (*apiextensionsv1.JSON)(unsafe.Pointer(in.Settings))
+	return nil
+}
+
+// Convert_v1beta2_BottlerocketConfig_To_v1beta1_BottlerocketConfig is an autogenerated conversion function.
+func Convert_v1beta2_BottlerocketConfig_To_v1beta1_BottlerocketConfig(in *v1beta2.BottlerocketConfig, out *BottlerocketConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_BottlerocketConfig_To_v1beta1_BottlerocketConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_BottlerocketHostContainer_To_v1beta2_BottlerocketHostContainer(in *BottlerocketHostContainer, out *v1beta2.BottlerocketHostContainer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Superpowered = in.Superpowered
+	out.Image = in.Image
+	out.UserData = in.UserData
+	return nil
+}
+
+// Convert_v1beta1_BottlerocketHostContainer_To_v1beta2_BottlerocketHostContainer is an autogenerated conversion function.
+func Convert_v1beta1_BottlerocketHostContainer_To_v1beta2_BottlerocketHostContainer(in *BottlerocketHostContainer, out *v1beta2.BottlerocketHostContainer, s conversion.Scope) error {
+	return autoConvert_v1beta1_BottlerocketHostContainer_To_v1beta2_BottlerocketHostContainer(in, out, s)
+}
+
+func autoConvert_v1beta2_BottlerocketHostContainer_To_v1beta1_BottlerocketHostContainer(in *v1beta2.BottlerocketHostContainer, out *BottlerocketHostContainer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Superpowered = in.Superpowered
+	out.Image = in.Image
+	out.UserData = in.UserData
+	return nil
+}
+
+// Convert_v1beta2_BottlerocketHostContainer_To_v1beta1_BottlerocketHostContainer is an autogenerated conversion function.
+func Convert_v1beta2_BottlerocketHostContainer_To_v1beta1_BottlerocketHostContainer(in *v1beta2.BottlerocketHostContainer, out *BottlerocketHostContainer, s conversion.Scope) error {
+	return autoConvert_v1beta2_BottlerocketHostContainer_To_v1beta1_BottlerocketHostContainer(in, out, s)
+}
+
+func autoConvert_v1beta1_CloudInitConfig_To_v1beta2_CloudInitConfig(in *CloudInitConfig, out *v1beta2.CloudInitConfig, s conversion.Scope) error {
+	out.Version = in.Version
+	out.EtcdReleaseURL = in.EtcdReleaseURL
+	out.InstallDir = in.InstallDir
+	out.ContainerdVersion = in.ContainerdVersion
+	return nil
+}
+
+// Convert_v1beta1_CloudInitConfig_To_v1beta2_CloudInitConfig is an autogenerated conversion function.
+func Convert_v1beta1_CloudInitConfig_To_v1beta2_CloudInitConfig(in *CloudInitConfig, out *v1beta2.CloudInitConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_CloudInitConfig_To_v1beta2_CloudInitConfig(in, out, s)
+}
+
+func autoConvert_v1beta2_CloudInitConfig_To_v1beta1_CloudInitConfig(in *v1beta2.CloudInitConfig, out *CloudInitConfig, s conversion.Scope) error {
+	out.Version = in.Version
+	out.EtcdReleaseURL = in.EtcdReleaseURL
+	out.InstallDir = in.InstallDir
+	out.ContainerdVersion = in.ContainerdVersion
+	return nil
+}
+
+// Convert_v1beta2_CloudInitConfig_To_v1beta1_CloudInitConfig is an autogenerated conversion function.
+func Convert_v1beta2_CloudInitConfig_To_v1beta1_CloudInitConfig(in *v1beta2.CloudInitConfig, out *CloudInitConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_CloudInitConfig_To_v1beta1_CloudInitConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfig_To_v1beta2_EtcdadmConfig(in *EtcdadmConfig, out *v1beta2.EtcdadmConfig, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_EtcdadmConfigSpec_To_v1beta2_EtcdadmConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_EtcdadmConfigStatus_To_v1beta2_EtcdadmConfigStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfig_To_v1beta2_EtcdadmConfig is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfig_To_v1beta2_EtcdadmConfig(in *EtcdadmConfig, out *v1beta2.EtcdadmConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfig_To_v1beta2_EtcdadmConfig(in, out, s)
+}
+
+func autoConvert_v1beta2_EtcdadmConfig_To_v1beta1_EtcdadmConfig(in *v1beta2.EtcdadmConfig, out *EtcdadmConfig, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta2_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta2_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta2_EtcdadmConfig_To_v1beta1_EtcdadmConfig is an autogenerated conversion function.
+func Convert_v1beta2_EtcdadmConfig_To_v1beta1_EtcdadmConfig(in *v1beta2.EtcdadmConfig, out *EtcdadmConfig, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdadmConfig_To_v1beta1_EtcdadmConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigList_To_v1beta2_EtcdadmConfigList(in *EtcdadmConfigList, out *v1beta2.EtcdadmConfigList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta2.EtcdadmConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_EtcdadmConfig_To_v1beta2_EtcdadmConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigList_To_v1beta2_EtcdadmConfigList is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigList_To_v1beta2_EtcdadmConfigList(in *EtcdadmConfigList, out *v1beta2.EtcdadmConfigList, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigList_To_v1beta2_EtcdadmConfigList(in, out, s)
+}
+
+func autoConvert_v1beta2_EtcdadmConfigList_To_v1beta1_EtcdadmConfigList(in *v1beta2.EtcdadmConfigList, out *EtcdadmConfigList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdadmConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_EtcdadmConfig_To_v1beta1_EtcdadmConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta2_EtcdadmConfigList_To_v1beta1_EtcdadmConfigList is an autogenerated conversion function.
+func Convert_v1beta2_EtcdadmConfigList_To_v1beta1_EtcdadmConfigList(in *v1beta2.EtcdadmConfigList, out *EtcdadmConfigList, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdadmConfigList_To_v1beta1_EtcdadmConfigList(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigSpec_To_v1beta2_EtcdadmConfigSpec(in *EtcdadmConfigSpec, out *v1beta2.EtcdadmConfigSpec, s conversion.Scope) error {
+	out.Users = *(*[]apiv1beta1.User)(unsafe.Pointer(&in.Users))
+	out.EtcdadmBuiltin = in.EtcdadmBuiltin
+	out.EtcdadmInstallCommands = *(*[]string)(unsafe.Pointer(&in.EtcdadmInstallCommands))
+	out.PreEtcdadmCommands = *(*[]string)(unsafe.Pointer(&in.PreEtcdadmCommands))
+	out.PostEtcdadmCommands = *(*[]string)(unsafe.Pointer(&in.PostEtcdadmCommands))
+	out.Format = v1beta2.Format(in.Format)
+	out.BottlerocketConfig = (*v1beta2.BottlerocketConfig)(unsafe.Pointer(in.BottlerocketConfig))
+	out.CloudInitConfig = (*v1beta2.CloudInitConfig)(unsafe.Pointer(in.CloudInitConfig))
+	out.Files = *(*[]apiv1beta1.File)(unsafe.Pointer(&in.Files))
+	out.Proxy = (*v1beta2.ProxyConfiguration)(unsafe.Pointer(in.Proxy))
+	out.RegistryMirror = (*v1beta2.RegistryMirrorConfiguration)(unsafe.Pointer(in.RegistryMirror))
+	out.CipherSuites = in.CipherSuites
+	out.NTP = (*apiv1beta1.NTP)(unsafe.Pointer(in.NTP))
+	out.CertBundles = *(*[]apiv1beta1.CertBundle)(unsafe.Pointer(&in.CertBundles))
+	out.Backup = (*v1beta2.BackupConfiguration)(unsafe.Pointer(in.Backup))
+	out.RestoreFrom = (*v1beta2.RestoreConfiguration)(unsafe.Pointer(in.RestoreFrom))
+	out.JoinAsLearner = in.JoinAsLearner
+	out.APIServerEtcdClientCertificate = (*v1beta2.APIServerEtcdClientCertificate)(unsafe.Pointer(in.APIServerEtcdClientCertificate))
+	out.Network = (*v1beta2.NetworkConfiguration)(unsafe.Pointer(in.Network))
+	out.Kernel = (*v1beta2.KernelConfiguration)(unsafe.Pointer(in.Kernel))
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigSpec_To_v1beta2_EtcdadmConfigSpec is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigSpec_To_v1beta2_EtcdadmConfigSpec(in *EtcdadmConfigSpec, out *v1beta2.EtcdadmConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigSpec_To_v1beta2_EtcdadmConfigSpec(in, out, s)
+}
+
+func autoConvert_v1beta2_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(in *v1beta2.EtcdadmConfigSpec, out *EtcdadmConfigSpec, s conversion.Scope) error {
+	out.Users = *(*[]apiv1beta1.User)(unsafe.Pointer(&in.Users))
+	out.EtcdadmBuiltin = in.EtcdadmBuiltin
+	out.EtcdadmInstallCommands = *(*[]string)(unsafe.Pointer(&in.EtcdadmInstallCommands))
+	out.PreEtcdadmCommands = *(*[]string)(unsafe.Pointer(&in.PreEtcdadmCommands))
+	out.PostEtcdadmCommands = *(*[]string)(unsafe.Pointer(&in.PostEtcdadmCommands))
+	out.Format = Format(in.Format)
+	out.BottlerocketConfig = (*BottlerocketConfig)(unsafe.Pointer(in.BottlerocketConfig))
+	out.CloudInitConfig = (*CloudInitConfig)(unsafe.Pointer(in.CloudInitConfig))
+	out.Files = *(*[]apiv1beta1.File)(unsafe.Pointer(&in.Files))
+	out.Proxy = (*ProxyConfiguration)(unsafe.Pointer(in.Proxy))
+	out.RegistryMirror = (*RegistryMirrorConfiguration)(unsafe.Pointer(in.RegistryMirror))
+	out.CipherSuites = in.CipherSuites
+	out.NTP = (*apiv1beta1.NTP)(unsafe.Pointer(in.NTP))
+	out.CertBundles = *(*[]apiv1beta1.CertBundle)(unsafe.Pointer(&in.CertBundles))
+	out.Backup = (*BackupConfiguration)(unsafe.Pointer(in.Backup))
+	out.RestoreFrom = (*RestoreConfiguration)(unsafe.Pointer(in.RestoreFrom))
+	out.JoinAsLearner = in.JoinAsLearner
+	out.APIServerEtcdClientCertificate = (*APIServerEtcdClientCertificate)(unsafe.Pointer(in.APIServerEtcdClientCertificate))
+	out.Network = (*NetworkConfiguration)(unsafe.Pointer(in.Network))
+	out.Kernel = (*KernelConfiguration)(unsafe.Pointer(in.Kernel))
+	return nil
+}
+
+// Convert_v1beta2_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec is an autogenerated conversion function.
+func Convert_v1beta2_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(in *v1beta2.EtcdadmConfigSpec, out *EtcdadmConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigStatus_To_v1beta2_EtcdadmConfigStatus(in *EtcdadmConfigStatus, out *v1beta2.EtcdadmConfigStatus, s conversion.Scope) error {
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			if err := clusterapiapiv1beta1.Convert_v1beta1_Condition_To_v1_Condition(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if err := v1.Convert_Pointer_string_To_string(&in.DataSecretName, &out.DataSecretName, s); err != nil {
+		return err
+	}
+	// WARNING: in.Ready requires manual conversion: does not exist in peer-type
+	// WARNING: in.V1Beta2 requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1beta2_EtcdadmConfigStatus_To_v1beta1_EtcdadmConfigStatus(in *v1beta2.EtcdadmConfigStatus, out *EtcdadmConfigStatus, s conversion.Scope) error {
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(clusterapiapiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			if err := clusterapiapiv1beta1.Convert_v1_Condition_To_v1beta1_Condition(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	// WARNING: in.Initialization requires manual conversion: does not exist in peer-type
+	if err := v1.Convert_string_To_Pointer_string(&in.DataSecretName, &out.DataSecretName, s); err != nil {
+		return err
+	}
+	// WARNING: in.ObservedGeneration requires manual conversion: does not exist in peer-type
+	// WARNING: in.Deprecated requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1beta1_EtcdadmConfigTemplate_To_v1beta2_EtcdadmConfigTemplate(in *EtcdadmConfigTemplate, out *v1beta2.EtcdadmConfigTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_EtcdadmConfigTemplateSpec_To_v1beta2_EtcdadmConfigTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigTemplate_To_v1beta2_EtcdadmConfigTemplate is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigTemplate_To_v1beta2_EtcdadmConfigTemplate(in *EtcdadmConfigTemplate, out *v1beta2.EtcdadmConfigTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigTemplate_To_v1beta2_EtcdadmConfigTemplate(in, out, s)
+}
+
+func autoConvert_v1beta2_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(in *v1beta2.EtcdadmConfigTemplate, out *EtcdadmConfigTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta2_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta2_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate is an autogenerated conversion function.
+func Convert_v1beta2_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(in *v1beta2.EtcdadmConfigTemplate, out *EtcdadmConfigTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigTemplateList_To_v1beta2_EtcdadmConfigTemplateList(in *EtcdadmConfigTemplateList, out *v1beta2.EtcdadmConfigTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta2.EtcdadmConfigTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_EtcdadmConfigTemplate_To_v1beta2_EtcdadmConfigTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigTemplateList_To_v1beta2_EtcdadmConfigTemplateList is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigTemplateList_To_v1beta2_EtcdadmConfigTemplateList(in *EtcdadmConfigTemplateList, out *v1beta2.EtcdadmConfigTemplateList, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigTemplateList_To_v1beta2_EtcdadmConfigTemplateList(in, out, s)
+}
+
+func autoConvert_v1beta2_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(in *v1beta2.EtcdadmConfigTemplateList, out *EtcdadmConfigTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdadmConfigTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_EtcdadmConfigTemplate_To_v1beta1_EtcdadmConfigTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta2_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList is an autogenerated conversion function.
+func Convert_v1beta2_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(in *v1beta2.EtcdadmConfigTemplateList, out *EtcdadmConfigTemplateList, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdadmConfigTemplateList_To_v1beta1_EtcdadmConfigTemplateList(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigTemplateResource_To_v1beta2_EtcdadmConfigTemplateResource(in *EtcdadmConfigTemplateResource, out *v1beta2.EtcdadmConfigTemplateResource, s conversion.Scope) error {
+	if err := clusterapiapiv1beta1.Convert_v1beta1_ObjectMeta_To_v1beta2_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_EtcdadmConfigSpec_To_v1beta2_EtcdadmConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigTemplateResource_To_v1beta2_EtcdadmConfigTemplateResource is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigTemplateResource_To_v1beta2_EtcdadmConfigTemplateResource(in *EtcdadmConfigTemplateResource, out *v1beta2.EtcdadmConfigTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigTemplateResource_To_v1beta2_EtcdadmConfigTemplateResource(in, out, s)
+}
+
+func autoConvert_v1beta2_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(in *v1beta2.EtcdadmConfigTemplateResource, out *EtcdadmConfigTemplateResource, s conversion.Scope) error {
+	if err := clusterapiapiv1beta1.Convert_v1beta2_ObjectMeta_To_v1beta1_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta2_EtcdadmConfigSpec_To_v1beta1_EtcdadmConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta2_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource is an autogenerated conversion function.
+func Convert_v1beta2_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(in *v1beta2.EtcdadmConfigTemplateResource, out *EtcdadmConfigTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdadmConfigTemplateSpec_To_v1beta2_EtcdadmConfigTemplateSpec(in *EtcdadmConfigTemplateSpec, out *v1beta2.EtcdadmConfigTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_EtcdadmConfigTemplateResource_To_v1beta2_EtcdadmConfigTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_EtcdadmConfigTemplateSpec_To_v1beta2_EtcdadmConfigTemplateSpec is an autogenerated conversion function.
+func Convert_v1beta1_EtcdadmConfigTemplateSpec_To_v1beta2_EtcdadmConfigTemplateSpec(in *EtcdadmConfigTemplateSpec, out *v1beta2.EtcdadmConfigTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdadmConfigTemplateSpec_To_v1beta2_EtcdadmConfigTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1beta2_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(in *v1beta2.EtcdadmConfigTemplateSpec, out *EtcdadmConfigTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1beta2_EtcdadmConfigTemplateResource_To_v1beta1_EtcdadmConfigTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta2_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec is an autogenerated conversion function.
+func Convert_v1beta2_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(in *v1beta2.EtcdadmConfigTemplateSpec, out *EtcdadmConfigTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1beta2_EtcdadmConfigTemplateSpec_To_v1beta1_EtcdadmConfigTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_HostEntry_To_v1beta2_HostEntry(in *HostEntry, out *v1beta2.HostEntry, s conversion.Scope) error {
+	out.IP = in.IP
+	out.Hostnames = *(*[]string)(unsafe.Pointer(&in.Hostnames))
+	return nil
+}
+
+// Convert_v1beta1_HostEntry_To_v1beta2_HostEntry is an autogenerated conversion function.
+func Convert_v1beta1_HostEntry_To_v1beta2_HostEntry(in *HostEntry, out *v1beta2.HostEntry, s conversion.Scope) error {
+	return autoConvert_v1beta1_HostEntry_To_v1beta2_HostEntry(in, out, s)
+}
+
+func autoConvert_v1beta2_HostEntry_To_v1beta1_HostEntry(in *v1beta2.HostEntry, out *HostEntry, s conversion.Scope) error {
+	out.IP = in.IP
+	out.Hostnames = *(*[]string)(unsafe.Pointer(&in.Hostnames))
+	return nil
+}
+
+// Convert_v1beta2_HostEntry_To_v1beta1_HostEntry is an autogenerated conversion function.
+func Convert_v1beta2_HostEntry_To_v1beta1_HostEntry(in *v1beta2.HostEntry, out *HostEntry, s conversion.Scope) error {
+	return autoConvert_v1beta2_HostEntry_To_v1beta1_HostEntry(in, out, s)
+}
+
+func autoConvert_v1beta1_KernelConfiguration_To_v1beta2_KernelConfiguration(in *KernelConfiguration, out *v1beta2.KernelConfiguration, s conversion.Scope) error {
+	out.SysctlSettings = *(*map[string]string)(unsafe.Pointer(&in.SysctlSettings))
+	out.BootKernelParameters = *(*map[string][]string)(unsafe.Pointer(&in.BootKernelParameters))
+	return nil
+}
+
+// Convert_v1beta1_KernelConfiguration_To_v1beta2_KernelConfiguration is an autogenerated conversion function.
+func Convert_v1beta1_KernelConfiguration_To_v1beta2_KernelConfiguration(in *KernelConfiguration, out *v1beta2.KernelConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta1_KernelConfiguration_To_v1beta2_KernelConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta2_KernelConfiguration_To_v1beta1_KernelConfiguration(in *v1beta2.KernelConfiguration, out *KernelConfiguration, s conversion.Scope) error {
+	out.SysctlSettings = *(*map[string]string)(unsafe.Pointer(&in.SysctlSettings))
+	out.BootKernelParameters = *(*map[string][]string)(unsafe.Pointer(&in.BootKernelParameters))
+	return nil
+}
+
+// Convert_v1beta2_KernelConfiguration_To_v1beta1_KernelConfiguration is an autogenerated conversion function.
+func Convert_v1beta2_KernelConfiguration_To_v1beta1_KernelConfiguration(in *v1beta2.KernelConfiguration, out *KernelConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta2_KernelConfiguration_To_v1beta1_KernelConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta1_NetworkConfiguration_To_v1beta2_NetworkConfiguration(in *NetworkConfiguration, out *v1beta2.NetworkConfiguration, s conversion.Scope) error {
+	out.NameServers = *(*[]string)(unsafe.Pointer(&in.NameServers))
+	out.SearchDomains = *(*[]string)(unsafe.Pointer(&in.SearchDomains))
+	out.Hosts = *(*[]v1beta2.HostEntry)(unsafe.Pointer(&in.Hosts))
+	return nil
+}
+
+// Convert_v1beta1_NetworkConfiguration_To_v1beta2_NetworkConfiguration is an autogenerated conversion function.
+func Convert_v1beta1_NetworkConfiguration_To_v1beta2_NetworkConfiguration(in *NetworkConfiguration, out *v1beta2.NetworkConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta1_NetworkConfiguration_To_v1beta2_NetworkConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta2_NetworkConfiguration_To_v1beta1_NetworkConfiguration(in *v1beta2.NetworkConfiguration, out *NetworkConfiguration, s conversion.Scope) error {
+	out.NameServers = *(*[]string)(unsafe.Pointer(&in.NameServers))
+	out.SearchDomains = *(*[]string)(unsafe.Pointer(&in.SearchDomains))
+	out.Hosts = *(*[]HostEntry)(unsafe.Pointer(&in.Hosts))
+	return nil
+}
+
+// Convert_v1beta2_NetworkConfiguration_To_v1beta1_NetworkConfiguration is an autogenerated conversion function.
+func Convert_v1beta2_NetworkConfiguration_To_v1beta1_NetworkConfiguration(in *v1beta2.NetworkConfiguration, out *NetworkConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta2_NetworkConfiguration_To_v1beta1_NetworkConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta1_ProxyConfiguration_To_v1beta2_ProxyConfiguration(in *ProxyConfiguration, out *v1beta2.ProxyConfiguration, s conversion.Scope) error {
+	out.HTTPProxy = in.HTTPProxy
+	out.HTTPSProxy = in.HTTPSProxy
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	out.AutoNoProxy = in.AutoNoProxy
+	return nil
+}
+
+// Convert_v1beta1_ProxyConfiguration_To_v1beta2_ProxyConfiguration is an autogenerated conversion function.
+func Convert_v1beta1_ProxyConfiguration_To_v1beta2_ProxyConfiguration(in *ProxyConfiguration, out *v1beta2.ProxyConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta1_ProxyConfiguration_To_v1beta2_ProxyConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta2_ProxyConfiguration_To_v1beta1_ProxyConfiguration(in *v1beta2.ProxyConfiguration, out *ProxyConfiguration, s conversion.Scope) error {
+	out.HTTPProxy = in.HTTPProxy
+	out.HTTPSProxy = in.HTTPSProxy
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	out.AutoNoProxy = in.AutoNoProxy
+	return nil
+}
+
+// Convert_v1beta2_ProxyConfiguration_To_v1beta1_ProxyConfiguration is an autogenerated conversion function.
+func Convert_v1beta2_ProxyConfiguration_To_v1beta1_ProxyConfiguration(in *v1beta2.ProxyConfiguration, out *ProxyConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta2_ProxyConfiguration_To_v1beta1_ProxyConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta1_RegistryCredentialsReference_To_v1beta2_RegistryCredentialsReference(in *RegistryCredentialsReference, out *v1beta2.RegistryCredentialsReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.UsernameKey = in.UsernameKey
+	out.PasswordKey = in.PasswordKey
+	return nil
+}
+
+// Convert_v1beta1_RegistryCredentialsReference_To_v1beta2_RegistryCredentialsReference is an autogenerated conversion function.
+func Convert_v1beta1_RegistryCredentialsReference_To_v1beta2_RegistryCredentialsReference(in *RegistryCredentialsReference, out *v1beta2.RegistryCredentialsReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_RegistryCredentialsReference_To_v1beta2_RegistryCredentialsReference(in, out, s)
+}
+
+func autoConvert_v1beta2_RegistryCredentialsReference_To_v1beta1_RegistryCredentialsReference(in *v1beta2.RegistryCredentialsReference, out *RegistryCredentialsReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.UsernameKey = in.UsernameKey
+	out.PasswordKey = in.PasswordKey
+	return nil
+}
+
+// Convert_v1beta2_RegistryCredentialsReference_To_v1beta1_RegistryCredentialsReference is an autogenerated conversion function.
+func Convert_v1beta2_RegistryCredentialsReference_To_v1beta1_RegistryCredentialsReference(in *v1beta2.RegistryCredentialsReference, out *RegistryCredentialsReference, s conversion.Scope) error {
+	return autoConvert_v1beta2_RegistryCredentialsReference_To_v1beta1_RegistryCredentialsReference(in, out, s)
+}
+
+func autoConvert_v1beta1_RegistryMirror_To_v1beta2_RegistryMirror(in *RegistryMirror, out *v1beta2.RegistryMirror, s conversion.Scope) error {
+	out.Upstream = in.Upstream
+	out.Endpoint = in.Endpoint
+	out.PathPrefix = in.PathPrefix
+	out.CACert = in.CACert
+	out.InsecureSkipVerify = in.InsecureSkipVerify
+	return nil
+}
+
+// Convert_v1beta1_RegistryMirror_To_v1beta2_RegistryMirror is an autogenerated conversion function.
+func Convert_v1beta1_RegistryMirror_To_v1beta2_RegistryMirror(in *RegistryMirror, out *v1beta2.RegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1beta1_RegistryMirror_To_v1beta2_RegistryMirror(in, out, s)
+}
+
+func autoConvert_v1beta2_RegistryMirror_To_v1beta1_RegistryMirror(in *v1beta2.RegistryMirror, out *RegistryMirror, s conversion.Scope) error {
+	out.Upstream = in.Upstream
+	out.Endpoint = in.Endpoint
+	out.PathPrefix = in.PathPrefix
+	out.CACert = in.CACert
+	out.InsecureSkipVerify = in.InsecureSkipVerify
+	return nil
+}
+
+// Convert_v1beta2_RegistryMirror_To_v1beta1_RegistryMirror is an autogenerated conversion function.
+func Convert_v1beta2_RegistryMirror_To_v1beta1_RegistryMirror(in *v1beta2.RegistryMirror, out *RegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1beta2_RegistryMirror_To_v1beta1_RegistryMirror(in, out, s)
+}
+
+func autoConvert_v1beta1_RegistryMirrorConfiguration_To_v1beta2_RegistryMirrorConfiguration(in *RegistryMirrorConfiguration, out *v1beta2.RegistryMirrorConfiguration, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.CACert = in.CACert
+	out.Mirrors = *(*[]v1beta2.RegistryMirror)(unsafe.Pointer(&in.Mirrors))
+	out.CredentialsRef = (*v1beta2.RegistryCredentialsReference)(unsafe.Pointer(in.CredentialsRef))
+	return nil
+}
+
+// Convert_v1beta1_RegistryMirrorConfiguration_To_v1beta2_RegistryMirrorConfiguration is an autogenerated conversion function.
+func Convert_v1beta1_RegistryMirrorConfiguration_To_v1beta2_RegistryMirrorConfiguration(in *RegistryMirrorConfiguration, out *v1beta2.RegistryMirrorConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta1_RegistryMirrorConfiguration_To_v1beta2_RegistryMirrorConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta2_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration(in *v1beta2.RegistryMirrorConfiguration, out *RegistryMirrorConfiguration, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.CACert = in.CACert
+	out.Mirrors = *(*[]RegistryMirror)(unsafe.Pointer(&in.Mirrors))
+	out.CredentialsRef = (*RegistryCredentialsReference)(unsafe.Pointer(in.CredentialsRef))
+	return nil
+}
+
+// Convert_v1beta2_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration is an autogenerated conversion function.
+func Convert_v1beta2_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration(in *v1beta2.RegistryMirrorConfiguration, out *RegistryMirrorConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta2_RegistryMirrorConfiguration_To_v1beta1_RegistryMirrorConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta1_RestoreConfiguration_To_v1beta2_RestoreConfiguration(in *RestoreConfiguration, out *v1beta2.RestoreConfiguration, s conversion.Scope) error {
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	out.SecretRef = (*v1beta2.SnapshotSourceReference)(unsafe.Pointer(in.SecretRef))
+	out.ConfigMapRef = (*v1beta2.SnapshotSourceReference)(unsafe.Pointer(in.ConfigMapRef))
+	return nil
+}
+
+// Convert_v1beta1_RestoreConfiguration_To_v1beta2_RestoreConfiguration is an autogenerated conversion function.
+func Convert_v1beta1_RestoreConfiguration_To_v1beta2_RestoreConfiguration(in *RestoreConfiguration, out *v1beta2.RestoreConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta1_RestoreConfiguration_To_v1beta2_RestoreConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta2_RestoreConfiguration_To_v1beta1_RestoreConfiguration(in *v1beta2.RestoreConfiguration, out *RestoreConfiguration, s conversion.Scope) error {
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	out.SecretRef = (*SnapshotSourceReference)(unsafe.Pointer(in.SecretRef))
+	out.ConfigMapRef = (*SnapshotSourceReference)(unsafe.Pointer(in.ConfigMapRef))
+	return nil
+}
+
+// Convert_v1beta2_RestoreConfiguration_To_v1beta1_RestoreConfiguration is an autogenerated conversion function.
+func Convert_v1beta2_RestoreConfiguration_To_v1beta1_RestoreConfiguration(in *v1beta2.RestoreConfiguration, out *RestoreConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta2_RestoreConfiguration_To_v1beta1_RestoreConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta1_S3BackupConfiguration_To_v1beta2_S3BackupConfiguration(in *S3BackupConfiguration, out *v1beta2.S3BackupConfiguration, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Bucket = in.Bucket
+	out.Prefix = in.Prefix
+	out.Region = in.Region
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return nil
+}
+
+// Convert_v1beta1_S3BackupConfiguration_To_v1beta2_S3BackupConfiguration is an autogenerated conversion function.
+func Convert_v1beta1_S3BackupConfiguration_To_v1beta2_S3BackupConfiguration(in *S3BackupConfiguration, out *v1beta2.S3BackupConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta1_S3BackupConfiguration_To_v1beta2_S3BackupConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta2_S3BackupConfiguration_To_v1beta1_S3BackupConfiguration(in *v1beta2.S3BackupConfiguration, out *S3BackupConfiguration, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Bucket = in.Bucket
+	out.Prefix = in.Prefix
+	out.Region = in.Region
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return nil
+}
+
+// Convert_v1beta2_S3BackupConfiguration_To_v1beta1_S3BackupConfiguration is an autogenerated conversion function.
+func Convert_v1beta2_S3BackupConfiguration_To_v1beta1_S3BackupConfiguration(in *v1beta2.S3BackupConfiguration, out *S3BackupConfiguration, s conversion.Scope) error {
+	return autoConvert_v1beta2_S3BackupConfiguration_To_v1beta1_S3BackupConfiguration(in, out, s)
+}
+
+func autoConvert_v1beta1_SnapshotSourceReference_To_v1beta2_SnapshotSourceReference(in *SnapshotSourceReference, out *v1beta2.SnapshotSourceReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1beta1_SnapshotSourceReference_To_v1beta2_SnapshotSourceReference is an autogenerated conversion function.
+func Convert_v1beta1_SnapshotSourceReference_To_v1beta2_SnapshotSourceReference(in *SnapshotSourceReference, out *v1beta2.SnapshotSourceReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_SnapshotSourceReference_To_v1beta2_SnapshotSourceReference(in, out, s)
+}
+
+func autoConvert_v1beta2_SnapshotSourceReference_To_v1beta1_SnapshotSourceReference(in *v1beta2.SnapshotSourceReference, out *SnapshotSourceReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1beta2_SnapshotSourceReference_To_v1beta1_SnapshotSourceReference is an autogenerated conversion function.
+func Convert_v1beta2_SnapshotSourceReference_To_v1beta1_SnapshotSourceReference(in *v1beta2.SnapshotSourceReference, out *SnapshotSourceReference, s conversion.Scope) error {
+	return autoConvert_v1beta2_SnapshotSourceReference_To_v1beta1_SnapshotSourceReference(in, out, s)
+}
@@ -22,11 +22,53 @@ limitations under the License.
 package v1beta1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
 	cluster_apiapiv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerEtcdClientCertificate) DeepCopyInto(out *APIServerEtcdClientCertificate) {
+	*out = *in
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerEtcdClientCertificate.
+func (in *APIServerEtcdClientCertificate) DeepCopy() *APIServerEtcdClientCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerEtcdClientCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfiguration) DeepCopyInto(out *BackupConfiguration) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackupConfiguration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfiguration.
+func (in *BackupConfiguration) DeepCopy() *BackupConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BottlerocketBootstrapContainer) DeepCopyInto(out *BottlerocketBootstrapContainer) {
 	*out = *in
@@ -65,6 +107,11 @@ func (in *BottlerocketConfig) DeepCopyInto(out *BottlerocketConfig) {
 		*out = new(apiv1beta1.BottlerocketBootSettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketConfig.
@@ -216,7 +263,7 @@ func (in *EtcdadmConfigSpec) DeepCopyInto(out *EtcdadmConfigSpec) {
 	if in.RegistryMirror != nil {
 		in, out := &in.RegistryMirror, &out.RegistryMirror
 		*out = new(RegistryMirrorConfiguration)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.NTP != nil {
 		in, out := &in.NTP, &out.NTP
@@ -228,6 +275,31 @@ func (in *EtcdadmConfigSpec) DeepCopyInto(out *EtcdadmConfigSpec) {
 		*out = make([]apiv1beta1.CertBundle, len(*in))
 		copy(*out, *in)
 	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerEtcdClientCertificate != nil {
+		in, out := &in.APIServerEtcdClientCertificate, &out.APIServerEtcdClientCertificate
+		*out = new(APIServerEtcdClientCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(NetworkConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kernel != nil {
+		in, out := &in.Kernel, &out.Kernel
+		*out = new(KernelConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigSpec.
@@ -255,6 +327,11 @@ func (in *EtcdadmConfigStatus) DeepCopyInto(out *EtcdadmConfigStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.V1Beta2 != nil {
+		in, out := &in.V1Beta2, &out.V1Beta2
+		*out = new(EtcdadmConfigV1Beta2Status)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigStatus.
@@ -267,6 +344,209 @@ func (in *EtcdadmConfigStatus) DeepCopy() *EtcdadmConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigTemplate) DeepCopyInto(out *EtcdadmConfigTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigTemplate.
+func (in *EtcdadmConfigTemplate) DeepCopy() *EtcdadmConfigTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdadmConfigTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigTemplateList) DeepCopyInto(out *EtcdadmConfigTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdadmConfigTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigTemplateList.
+func (in *EtcdadmConfigTemplateList) DeepCopy() *EtcdadmConfigTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdadmConfigTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigTemplateResource) DeepCopyInto(out *EtcdadmConfigTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigTemplateResource.
+func (in *EtcdadmConfigTemplateResource) DeepCopy() *EtcdadmConfigTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigTemplateSpec) DeepCopyInto(out *EtcdadmConfigTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigTemplateSpec.
+func (in *EtcdadmConfigTemplateSpec) DeepCopy() *EtcdadmConfigTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdadmConfigV1Beta2Status) DeepCopyInto(out *EtcdadmConfigV1Beta2Status) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdadmConfigV1Beta2Status.
+func (in *EtcdadmConfigV1Beta2Status) DeepCopy() *EtcdadmConfigV1Beta2Status {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdadmConfigV1Beta2Status)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostEntry) DeepCopyInto(out *HostEntry) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostEntry.
+func (in *HostEntry) DeepCopy() *HostEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(HostEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelConfiguration) DeepCopyInto(out *KernelConfiguration) {
+	*out = *in
+	if in.SysctlSettings != nil {
+		in, out := &in.SysctlSettings, &out.SysctlSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BootKernelParameters != nil {
+		in, out := &in.BootKernelParameters, &out.BootKernelParameters
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelConfiguration.
+func (in *KernelConfiguration) DeepCopy() *KernelConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
+	*out = *in
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]HostEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkConfiguration.
+func (in *NetworkConfiguration) DeepCopy() *NetworkConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyConfiguration) DeepCopyInto(out *ProxyConfiguration) {
 	*out = *in
@@ -287,9 +567,49 @@ func (in *ProxyConfiguration) DeepCopy() *ProxyConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryCredentialsReference) DeepCopyInto(out *RegistryCredentialsReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryCredentialsReference.
+func (in *RegistryCredentialsReference) DeepCopy() *RegistryCredentialsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryCredentialsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryMirrorConfiguration) DeepCopyInto(out *RegistryMirrorConfiguration) {
 	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]RegistryMirror, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(RegistryCredentialsReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirrorConfiguration.
@@ -301,3 +621,59 @@ func (in *RegistryMirrorConfiguration) DeepCopy() *RegistryMirrorConfiguration {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreConfiguration) DeepCopyInto(out *RestoreConfiguration) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SnapshotSourceReference)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(SnapshotSourceReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreConfiguration.
+func (in *RestoreConfiguration) DeepCopy() *RestoreConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupConfiguration) DeepCopyInto(out *S3BackupConfiguration) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupConfiguration.
+func (in *S3BackupConfiguration) DeepCopy() *S3BackupConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotSourceReference) DeepCopyInto(out *SnapshotSourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSourceReference.
+func (in *SnapshotSourceReference) DeepCopy() *SnapshotSourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotSourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,13 @@
+package v1beta2
+
+// Hub marks EtcdadmConfig as a conversion hub.
+func (*EtcdadmConfig) Hub() {}
+
+// Hub marks EtcdadmConfigList as a conversion hub.
+func (*EtcdadmConfigList) Hub() {}
+
+// Hub marks EtcdadmConfigTemplate as a conversion hub.
+func (*EtcdadmConfigTemplate) Hub() {}
+
+// Hub marks EtcdadmConfigTemplateList as a conversion hub.
+func (*EtcdadmConfigTemplateList) Hub() {}
@@ -0,0 +1,659 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+const (
+	// EtcdMemberFinalizer allows the controller to remove the etcd member of a Machine before the Machine goes away.
+	EtcdMemberFinalizer = "etcdadmconfig.bootstrap.cluster.x-k8s.io/etcd-member"
+	// PreviewAnnotation makes the controller render the bootstrap data of the current spec of an EtcdadmConfig into the
+	// "<name>-preview" Secret, with the etcd CA key and credentials redacted. The bootstrap data Secret of the Machine is
+	// unaffected.
+	PreviewAnnotation = "etcdadmconfig.bootstrap.cluster.x-k8s.io/preview"
+	// PreviewSpecHashLabel is set on preview Secrets to the hash of the EtcdadmConfigSpec they were rendered from.
+	PreviewSpecHashLabel = "etcdadmconfig.bootstrap.cluster.x-k8s.io/spec-hash"
+	// APIServerEtcdClientCertificateLabel is set on the kube-apiserver etcd client certificate Secrets issued by the
+	// controller. Secrets without it are never renewed.
+	APIServerEtcdClientCertificateLabel = "etcdadmconfig.bootstrap.cluster.x-k8s.io/apiserver-etcd-client"
+	// CloudConfig make the bootstrap data to be of cloud-config format.
+	CloudConfig Format = "cloud-config"
+	// Bottlerocket make the bootstrap data to be of bottlerocket format.
+	Bottlerocket Format = "bottlerocket"
+)
+
+// EtcdadmConfig's DataSecretAvailable condition and corresponding reasons.
+const (
+	// DataSecretAvailableCondition documents the status of the bootstrap secret generation process.
+	DataSecretAvailableCondition = "DataSecretAvailable"
+	// DataSecretAvailableReason surfaces when the bootstrap secret is available.
+	DataSecretAvailableReason = clusterv1.AvailableReason
+	// DataSecretNotAvailableReason surfaces when the bootstrap secret has not been generated yet.
+	DataSecretNotAvailableReason = clusterv1.NotAvailableReason
+	// RegistryCredentialsUnavailableReason surfaces when the registry credentials the config references cannot be read.
+	RegistryCredentialsUnavailableReason = "RegistryCredentialsUnavailable"
+	// RestoreSnapshotTooLargeReason surfaces when the bootstrap data embedding the snapshot to restore from is too large.
+	RestoreSnapshotTooLargeReason = "RestoreSnapshotTooLarge"
+)
+
+// EtcdadmConfig's EtcdMemberHealthy condition and corresponding reasons.
+const (
+	// EtcdMemberHealthyCondition reports whether the etcd member of the Machine serves linearizable reads without errors.
+	EtcdMemberHealthyCondition = "EtcdMemberHealthy"
+	// EtcdMemberHealthyReason is used when the etcd member serves reads and reports no errors.
+	EtcdMemberHealthyReason = "EtcdMemberHealthy"
+	// EtcdMemberUnhealthyReason is used when the etcd member answers but cannot serve reads or reports errors such as alarms.
+	EtcdMemberUnhealthyReason = "EtcdMemberUnhealthy"
+	// EtcdMemberUnreachableReason is used when the etcd member cannot be reached through its client endpoint.
+	EtcdMemberUnreachableReason = "EtcdMemberUnreachable"
+	// WaitingForMachineAddressReason is used while the Machine has no address to reach its etcd member on.
+	WaitingForMachineAddressReason = "WaitingForMachineAddress"
+)
+
+// EtcdadmConfig's APIServerEtcdClientCertificateAvailable condition and corresponding reasons.
+const (
+	// APIServerEtcdClientCertificateAvailableCondition reports whether the kube-apiserver etcd client certificate Secret
+	// holds a certificate for the configured common name, signed by the etcd CA and not due for renewal.
+	APIServerEtcdClientCertificateAvailableCondition = "APIServerEtcdClientCertificateAvailable"
+	// APIServerEtcdClientCertificateAvailableReason surfaces when the certificate is available.
+	APIServerEtcdClientCertificateAvailableReason = clusterv1.AvailableReason
+	// APIServerEtcdClientSecretNotManagedReason surfaces when the certificate has to be renewed, but its Secret was not
+	// created by the controller and is left alone.
+	APIServerEtcdClientSecretNotManagedReason = "APIServerEtcdClientSecretNotManaged"
+)
+
+// EtcdadmConfig's RegistryCredentialsAvailable condition and corresponding reasons.
+const (
+	// RegistryCredentialsAvailableCondition reports whether the bootstrap data of a config with a registry mirror was
+	// rendered with registry credentials.
+	RegistryCredentialsAvailableCondition = "RegistryCredentialsAvailable"
+	// RegistryCredentialsAvailableReason surfaces when the registry credentials were read.
+	RegistryCredentialsAvailableReason = clusterv1.AvailableReason
+	// RegistryCredentialsNotFoundReason surfaces when the config does not reference registry credentials and the
+	// "registry-credentials" Secret cannot be read, so the bootstrap data is rendered without credentials.
+	RegistryCredentialsNotFoundReason = "RegistryCredentialsNotFound"
+)
+
+// Conditions and reasons of the deprecated v1beta1 status, kept up to date until support for v1beta1 is dropped.
+const (
+	// DataSecretAvailableV1Beta1Condition documents the status of the bootstrap secret generation process.
+	DataSecretAvailableV1Beta1Condition clusterv1.ConditionType = "DataSecretAvailable"
+	// EtcdMemberHealthyV1Beta1Condition reports whether the etcd member of the Machine serves linearizable reads without errors.
+	EtcdMemberHealthyV1Beta1Condition clusterv1.ConditionType = "EtcdMemberHealthy"
+	// APIServerEtcdClientCertificateAvailableV1Beta1Condition reports whether the kube-apiserver etcd client certificate
+	// Secret holds a usable certificate.
+	APIServerEtcdClientCertificateAvailableV1Beta1Condition clusterv1.ConditionType = "APIServerEtcdClientCertificateAvailable"
+	// RegistryCredentialsAvailableV1Beta1Condition reports whether the bootstrap data was rendered with registry
+	// credentials.
+	RegistryCredentialsAvailableV1Beta1Condition clusterv1.ConditionType = "RegistryCredentialsAvailable"
+)
+
+// Format specifies the output format of the bootstrap data
+type Format string
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// EtcdadmConfigSpec defines the desired state of EtcdadmConfig
+type EtcdadmConfigSpec struct {
+	// Users specifies extra users to add
+	// +optional
+	Users []capbk.User `json:"users,omitempty"`
+
+	// +optional
+	EtcdadmBuiltin bool `json:"etcdadmBuiltin,omitempty"`
+
+	// +optional
+	EtcdadmInstallCommands []string `json:"etcdadmInstallCommands,omitempty"`
+
+	// PreEtcdadmCommands specifies extra commands to run before kubeadm runs
+	// +optional
+	PreEtcdadmCommands []string `json:"preEtcdadmCommands,omitempty"`
+
+	// PostEtcdadmCommands specifies extra commands to run after kubeadm runs
+	// +optional
+	PostEtcdadmCommands []string `json:"postEtcdadmCommands,omitempty"`
+
+	// Format specifies the output format of the bootstrap data: cloud-config, the default, bottlerocket, or a format
+	// registered by a downstream build. The webhook rejects formats no renderer is registered for.
+	// +optional
+	Format Format `json:"format,omitempty"`
+
+	// BottlerocketConfig specifies the configuration for the bottlerocket bootstrap data
+	// +optional
+	BottlerocketConfig *BottlerocketConfig `json:"bottlerocketConfig,omitempty"`
+
+	// CloudInitConfig specifies the configuration for the cloud-init bootstrap data
+	// +optional
+	CloudInitConfig *CloudInitConfig `json:"cloudInitConfig,omitempty"`
+
+	// Files specifies extra files to be passed to user_data upon creation.
+	// +optional
+	Files []capbk.File `json:"files,omitempty"`
+
+	// Proxy holds the http, https and no proxy information. With cloud-config it is also exported to etcdadm and the
+	// commands that install it.
+	// +optional
+	Proxy *ProxyConfiguration `json:"proxy,omitempty"`
+
+	// RegistryMirror holds the image registry mirror information
+	// This is only used for bottlerocket
+	// +optional
+	RegistryMirror *RegistryMirrorConfiguration `json:"registryMirror,omitempty"`
+
+	// CipherSuites is a list of comma-delimited supported TLS cipher suites, mapping to the --cipher-suites flag.
+	// Default is empty, which means that they will be auto-populated by Go.
+	// +optional
+	CipherSuites string `json:"cipherSuites,omitempty"`
+
+	// NTP specifies NTP configuration
+	// +optional
+	NTP *capbk.NTP `json:"ntp,omitempty"`
+
+	// CertBundles holds additional CA certificates the machines trust: as settings.pki entries on Bottlerocket, and
+	// added to the trust store of the distro before any other command runs with cloud-config.
+	// +optional
+	CertBundles []capbk.CertBundle `json:"certBundles,omitempty"`
+
+	// Backup holds the settings for scheduled etcd snapshot backups
+	// +optional
+	Backup *BackupConfiguration `json:"backup,omitempty"`
+
+	// RestoreFrom initializes the etcd cluster from an existing snapshot instead of starting it empty.
+	// It is only used by the machine initializing the cluster, members joining afterwards are unaffected.
+	// This is only used for cloud-config
+	// +optional
+	RestoreFrom *RestoreConfiguration `json:"restoreFrom,omitempty"`
+
+	// JoinAsLearner makes joining members enter the cluster as non-voting learners. A learner is promoted
+	// to a voting member once it has caught up with the leader, and the bootstrap is only reported
+	// successful after the promotion, except with bottlerocket, where the learner is promoted by a host container
+	// running alongside the bootstrap and bottlerocketConfig.learnerImage is required.
+	// +optional
+	JoinAsLearner bool `json:"joinAsLearner,omitempty"`
+
+	// APIServerEtcdClientCertificate makes the controller issue and renew the client certificate the kube-apiserver
+	// uses to connect to etcd, signed by the etcd CA of the cluster.
+	// +optional
+	APIServerEtcdClientCertificate *APIServerEtcdClientCertificate `json:"apiServerEtcdClientCertificate,omitempty"`
+
+	// Network holds the DNS and static host settings of etcd machines.
+	// +optional
+	Network *NetworkConfiguration `json:"network,omitempty"`
+
+	// Kernel holds the sysctl settings and boot parameters of the kernel of etcd machines.
+	// +optional
+	Kernel *KernelConfiguration `json:"kernel,omitempty"`
+}
+
+type BottlerocketConfig struct {
+	// EtcdImage specifies the etcd image to use by etcdadm
+	EtcdImage string `json:"etcdImage,omitempty"`
+
+	// BootstrapImage specifies the container image to use for bottlerocket's bootstrapping
+	BootstrapImage string `json:"bootstrapImage"`
+
+	// AdminImage specifies the admin container image to use for bottlerocket.
+	// +optional
+	AdminImage string `json:"adminImage,omitempty"`
+
+	// ControlImage specifies the control container image to use for bottlerocket.
+	// +optional
+	ControlImage string `json:"controlImage,omitempty"`
+
+	// LearnerImage specifies the image of the etcd-learner containers, required to join members as learners.
+	// A bootstrap container adds the member as a learner before it joins, and a host container promotes it once it
+	// has caught up with the leader. Both run the bash script in their user data, and need curl, openssl and ip.
+	// +optional
+	LearnerImage string `json:"learnerImage,omitempty"`
+
+	// PauseImage specifies the image to use for the pause container
+	PauseImage string `json:"pauseImage"`
+
+	// CustomHostContainers adds additional host containers for bottlerocket.
+	// +optional
+	CustomHostContainers []BottlerocketHostContainer `json:"customHostContainers,omitempty"`
+
+	// CustomBootstrapContainers adds additional bootstrap containers for bottlerocket.
+	// +optional
+	CustomBootstrapContainers []BottlerocketBootstrapContainer `json:"customBootstrapContainers,omitempty"`
+
+	// Kernel specifies additional kernel settings for bottlerocket
+	Kernel *capbk.BottlerocketKernelSettings `json:"kernel,omitempty"`
+
+	// Boot specifies boot settings for bottlerocket
+	Boot *capbk.BottlerocketBootSettings `json:"boot,omitempty"`
+
+	// Settings are Bottlerocket settings, keyed as under `settings` in the Bottlerocket user data, that are merged
+	// over the settings generated from the other fields. Tables are merged key by key, any other value replaces the
+	// generated one. The bootstrap and backup host containers and the hostname are owned by the provider and may not
+	// be set.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Settings *apiextensionsv1.JSON `json:"settings,omitempty"`
+}
+
+// BottlerocketHostContainer holds the host container setting for bottlerocket.
+type BottlerocketHostContainer struct {
+	// Name is the host container name that will be given to the container in BR's `apiserver`
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Superpowered indicates if the container will be superpowered
+	// +kubebuilder:validation:Required
+	Superpowered bool `json:"superpowered"`
+
+	// Image is the actual location of the host container image.
+	Image string `json:"image"`
+
+	// UserData is the userdata that will be attached to the image.
+	// +optional
+	UserData string `json:"userData,omitempty"`
+}
+
+// BottlerocketBootstrapContainer holds the bootstrap container setting for bottlerocket.
+type BottlerocketBootstrapContainer struct {
+	// Name is the bootstrap container name that will be given to the container in BR's `apiserver`.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Image is the actual image used for Bottlerocket bootstrap.
+	Image string `json:"image"`
+
+	// Essential decides whether or not the container should fail the boot process.
+	// Bootstrap containers configured with essential = true will stop the boot process if they exit code is a non-zero value.
+	// Default is false.
+	// +optional
+	Essential bool `json:"essential"`
+
+	// Mode represents the bootstrap container mode.
+	// +kubebuilder:validation:Enum=always;off;once
+	Mode string `json:"mode"`
+
+	// UserData is the base64-encoded userdata.
+	// +optional
+	UserData string `json:"userData,omitempty"`
+}
+
+type CloudInitConfig struct {
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// EtcdReleaseURL is an optional field to specify where etcdadm can download etcd from
+	// +optional
+	EtcdReleaseURL string `json:"etcdReleaseURL,omitempty"`
+
+	// InstallDir is an optional field to specify where etcdadm will extract etcd binaries to
+	// +optional
+	InstallDir string `json:"installDir,omitempty"`
+
+	// ContainerdVersion is the version of containerd in the machine image, such as 1.7.20 or 2.0.0. Containerd 1.x
+	// needs its registry config_path set to read the hosts.toml files registry mirrors are written to, while 2.x
+	// reads them by default. When empty, the version is read from containerd on the machine.
+	// +kubebuilder:validation:Pattern=`^v?[0-9]+(\.[0-9]+){0,2}$`
+	// +optional
+	ContainerdVersion string `json:"containerdVersion,omitempty"`
+}
+
+// ProxyConfiguration holds the settings for proxying bottlerocket services
+type ProxyConfiguration struct {
+	// HTTP Proxy
+	// Bottlerocket has a single proxy setting, so it only uses HTTPProxy when HTTPSProxy is not set.
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPS proxy
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// No proxy, list of ips that should not use proxy
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// AutoNoProxy adds the pod and service CIDRs and the control plane endpoint of the Cluster, the address of the etcd
+	// member joined and the addresses of the etcd Machines to NoProxy when the bootstrap data is generated.
+	// +optional
+	AutoNoProxy bool `json:"autoNoProxy,omitempty"`
+}
+
+// RegistryMirrorConfiguration holds the settings for image registry mirror
+type RegistryMirrorConfiguration struct {
+	// Endpoint defines the registry mirror endpoint to use for pulling images
+	// It mirrors public.ecr.aws.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CACert defines the CA cert for the registry mirror
+	CACert string `json:"caCert,omitempty"`
+
+	// Mirrors are the mirrors of other upstream registries.
+	// +optional
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+
+	// CredentialsRef references the Secret holding the credentials of the mirrors. When it is set, the bootstrap data
+	// is not generated until the Secret can be read. Otherwise, the credentials are read from the
+	// "registry-credentials" Secret, if it exists.
+	// +optional
+	CredentialsRef *RegistryCredentialsReference `json:"credentialsRef,omitempty"`
+}
+
+// RegistryCredentialsReference references a Secret in the EtcdadmConfig namespace holding registry credentials:
+// either a kubernetes.io/dockerconfigjson Secret, with credentials for each registry, or a Secret holding a username
+// and a password used for every mirror.
+type RegistryCredentialsReference struct {
+	// Name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// UsernameKey is the key holding the username. Defaults to "username".
+	// Ignored for kubernetes.io/dockerconfigjson Secrets.
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key holding the password. Defaults to "password".
+	// Ignored for kubernetes.io/dockerconfigjson Secrets.
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// RegistryMirror maps an upstream registry to the mirror its images are pulled from.
+type RegistryMirror struct {
+	// Upstream is the host of the registry mirrored, such as docker.io, registry.k8s.io or quay.io.
+	// +kubebuilder:validation:MinLength=1
+	Upstream string `json:"upstream"`
+
+	// Endpoint is the host, and optionally the port, of the mirror.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// PathPrefix is the path the images of the upstream registry are served under on the mirror, such as the
+	// project of a Harbor registry.
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// CACert is the PEM encoded CA certificate of the mirror.
+	// +optional
+	CACert string `json:"caCert,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of the mirror.
+	// This is not supported with bottlerocket.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// NetworkConfiguration holds the network settings of etcd machines on networks without DHCP provided DNS.
+// On bottlerocket they are rendered into the network and dns settings, on cloud-config into resolv_conf and
+// /etc/hosts.
+type NetworkConfiguration struct {
+	// NameServers are the IP addresses of the DNS servers the machine resolves names with.
+	// +optional
+	NameServers []string `json:"nameServers,omitempty"`
+
+	// SearchDomains are the domains searched when resolving names that are not fully qualified.
+	// +optional
+	SearchDomains []string `json:"searchDomains,omitempty"`
+
+	// Hosts are static entries added to /etc/hosts.
+	// +optional
+	Hosts []HostEntry `json:"hosts,omitempty"`
+}
+
+// KernelConfiguration holds the kernel settings of etcd machines. On bottlerocket they are rendered into the kernel and
+// boot settings, where BottlerocketConfig.Kernel and BottlerocketConfig.Boot take precedence over them. On cloud-config
+// the sysctl settings are written to /etc/sysctl.d and applied before etcdadm runs, and the boot parameters are added
+// to the kernel command line of the bootloader, after which the machine reboots once before anything else runs.
+type KernelConfiguration struct {
+	// SysctlSettings are the sysctl settings of the kernel, keyed by name.
+	// +optional
+	SysctlSettings map[string]string `json:"sysctlSettings,omitempty"`
+
+	// BootKernelParameters are the parameters of the kernel command line, keyed by name. A parameter is added once
+	// for each of its values as name=value, or as name alone when it has no value.
+	// +optional
+	BootKernelParameters map[string][]string `json:"bootKernelParameters,omitempty"`
+}
+
+// HostEntry maps an IP address to host names.
+type HostEntry struct {
+	// IP is the address the host names resolve to.
+	IP string `json:"ip"`
+
+	// Hostnames are the names resolving to IP.
+	// +kubebuilder:validation:MinItems=1
+	Hostnames []string `json:"hostnames"`
+}
+
+// BackupConfiguration holds the settings for scheduled etcd snapshot backups.
+// On cloud-config a systemd timer runs `etcdctl snapshot save`, on bottlerocket a host container does.
+type BackupConfiguration struct {
+	// Schedule is a systemd calendar expression defining when snapshots are taken.
+	// Defaults to "hourly".
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Retention is the number of snapshots to keep in each destination.
+	// Older snapshots are removed after every successful backup. Zero keeps all snapshots.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retention int32 `json:"retention,omitempty"`
+
+	// LocalPath is the directory on the etcd node snapshots are written to.
+	// Defaults to "/var/lib/etcd-backup".
+	// +optional
+	LocalPath string `json:"localPath,omitempty"`
+
+	// S3 uploads every snapshot to an S3-compatible object store.
+	// +optional
+	S3 *S3BackupConfiguration `json:"s3,omitempty"`
+
+	// Image is the host container image running the backups.
+	// The container receives the backup settings as an environment file in its user data.
+	// This is only used for bottlerocket
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// S3BackupConfiguration holds the settings for uploading etcd snapshots to an S3-compatible endpoint
+type S3BackupConfiguration struct {
+	// Endpoint is the URL of the S3-compatible service, for example https://s3.us-west-2.amazonaws.com or http://minio:9000.
+	// Objects are addressed path-style as <endpoint>/<bucket>/<prefix><snapshot>.
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the bucket snapshots are uploaded to.
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the snapshot object names.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region used to sign requests. Defaults to "us-east-1".
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecretRef references a Secret in the EtcdadmConfig namespace
+	// holding the "accessKeyID" and "secretAccessKey" keys.
+	// The credentials are written in plaintext into the bootstrap data of the machines.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// RestoreConfiguration holds the source of the snapshot a new etcd cluster is restored from.
+// Exactly one of URL, SecretRef and ConfigMapRef must be set.
+type RestoreConfiguration struct {
+	// URL is the location the snapshot is downloaded from.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum the snapshot is verified against before it is restored.
+	// Required when URL is set.
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+
+	// SecretRef references a key of a Secret in the EtcdadmConfig namespace holding the snapshot.
+	// +optional
+	SecretRef *SnapshotSourceReference `json:"secretRef,omitempty"`
+
+	// ConfigMapRef references a binaryData key of a ConfigMap in the EtcdadmConfig namespace holding the snapshot.
+	// +optional
+	ConfigMapRef *SnapshotSourceReference `json:"configMapRef,omitempty"`
+}
+
+// SnapshotSourceReference references a key of a Secret or ConfigMap holding an etcd snapshot
+type SnapshotSourceReference struct {
+	// Name of the Secret or ConfigMap.
+	Name string `json:"name"`
+
+	// Key holding the snapshot. Defaults to "snapshot.db".
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// APIServerEtcdClientCertificate configures the kube-apiserver etcd client certificate of a cluster.
+// The certificate is stored in the tls.crt and tls.key keys of a Secret owned by the Cluster and
+// renewed once less than a third of its validity is left.
+type APIServerEtcdClientCertificate struct {
+	// SecretName is the name of the Secret holding the certificate. Defaults to "<cluster name>-apiserver-etcd-client".
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// CommonName of the certificate. Defaults to "kube-apiserver-etcd-client".
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// Validity of the certificate. Defaults to one year.
+	// +optional
+	Validity *metav1.Duration `json:"validity,omitempty"`
+}
+
+// EtcdadmConfigStatus defines the observed state of EtcdadmConfig
+// +kubebuilder:validation:MinProperties=1
+type EtcdadmConfigStatus struct {
+	// Conditions represents the observations of an EtcdadmConfig's current state.
+	// Known condition types are Ready, DataSecretAvailable and EtcdMemberHealthy.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Initialization provides observations of the EtcdadmConfig initialization process.
+	// NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial Machine provisioning.
+	// +optional
+	Initialization EtcdadmConfigInitializationStatus `json:"initialization,omitempty,omitzero"`
+
+	// DataSecretName is the name of the secret that stores the bootstrap data.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	DataSecretName string `json:"dataSecretName,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Deprecated groups all the status fields that are deprecated and will be removed when all the nested fields are removed.
+	// +optional
+	Deprecated *EtcdadmConfigDeprecatedStatus `json:"deprecated,omitempty"`
+}
+
+// EtcdadmConfigInitializationStatus provides observations of the EtcdadmConfig initialization process.
+// +kubebuilder:validation:MinProperties=1
+type EtcdadmConfigInitializationStatus struct {
+	// DataSecretCreated is true when the Machine's bootstrap secret is created.
+	// NOTE: this field is part of the Cluster API contract, and it is used to orchestrate initial Machine provisioning.
+	// +optional
+	DataSecretCreated *bool `json:"dataSecretCreated,omitempty"`
+}
+
+// EtcdadmConfigDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
+type EtcdadmConfigDeprecatedStatus struct {
+	// V1Beta1 groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
+	// +optional
+	V1Beta1 *EtcdadmConfigV1Beta1DeprecatedStatus `json:"v1beta1,omitempty"`
+}
+
+// EtcdadmConfigV1Beta1DeprecatedStatus groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
+type EtcdadmConfigV1Beta1DeprecatedStatus struct {
+	// Conditions defines current service state of the EtcdadmConfig.
+	//
+	// Deprecated: This field is deprecated and is going to be removed when support for v1beta1 will be dropped.
+	//
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels['cluster\\.x-k8s\\.io/cluster-name']",description="Cluster"
+// +kubebuilder:printcolumn:name="Data secret created",type="string",JSONPath=`.status.initialization.dataSecretCreated`,description="Bootstrap secret is created"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of EtcdadmConfig"
+// EtcdadmConfig is the Schema for the etcdadmconfigs API
+type EtcdadmConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdadmConfigSpec   `json:"spec,omitempty"`
+	Status EtcdadmConfigStatus `json:"status,omitempty"`
+}
+
+// GetV1Beta1Conditions returns the set of v1beta1 conditions for this object.
+func (e *EtcdadmConfig) GetV1Beta1Conditions() clusterv1.Conditions {
+	if e.Status.Deprecated == nil || e.Status.Deprecated.V1Beta1 == nil {
+		return nil
+	}
+	return e.Status.Deprecated.V1Beta1.Conditions
+}
+
+// SetV1Beta1Conditions sets the v1beta1 conditions on this object.
+func (e *EtcdadmConfig) SetV1Beta1Conditions(conditions clusterv1.Conditions) {
+	if e.Status.Deprecated == nil {
+		e.Status.Deprecated = &EtcdadmConfigDeprecatedStatus{}
+	}
+	if e.Status.Deprecated.V1Beta1 == nil {
+		e.Status.Deprecated.V1Beta1 = &EtcdadmConfigV1Beta1DeprecatedStatus{}
+	}
+	e.Status.Deprecated.V1Beta1.Conditions = conditions
+}
+
+// GetConditions returns the set of conditions for this object.
+func (e *EtcdadmConfig) GetConditions() []metav1.Condition {
+	return e.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (e *EtcdadmConfig) SetConditions(conditions []metav1.Condition) {
+	e.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdadmConfigList contains a list of EtcdadmConfig
+type EtcdadmConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdadmConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdadmConfig{}, &EtcdadmConfigList{})
+}
@@ -0,0 +1,550 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var etcdadmconfiglog = logf.Log.WithName("etcdadmconfig-resource")
+
+func (r *EtcdadmConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(r).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-bootstrap-cluster-x-k8s-io-v1beta2-etcdadmconfig,mutating=true,failurePolicy=fail,groups=bootstrap.cluster.x-k8s.io,resources=etcdadmconfigs,verbs=create;update,versions=v1beta2,name=metcdadmconfig.kb.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.CustomDefaulter = &EtcdadmConfig{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (r *EtcdadmConfig) Default(_ context.Context, obj runtime.Object) error {
+	etcdadmConfig, ok := obj.(*EtcdadmConfig)
+	if !ok {
+		return fmt.Errorf("expected an EtcdadmConfig but got %T", obj)
+	}
+
+	etcdadmconfiglog.Info("default", "name", etcdadmConfig.Name)
+
+	// TODO(user): fill in your defaulting logic.
+	return nil
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:verbs=create;update,path=/validate-bootstrap-cluster-x-k8s-io-v1beta2-etcdadmconfig,mutating=false,failurePolicy=fail,groups=bootstrap.cluster.x-k8s.io,resources=etcdadmconfigs,versions=v1beta2,name=vetcdadmconfig.kb.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.CustomValidator = &EtcdadmConfig{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *EtcdadmConfig) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	etcdadmConfig, ok := obj.(*EtcdadmConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected an EtcdadmConfig but got %T", obj)
+	}
+
+	etcdadmconfiglog.Info("validate create", "name", etcdadmConfig.Name)
+
+	return etcdadmConfig.Spec.warnings(field.NewPath("spec")), etcdadmConfig.validate(nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *EtcdadmConfig) ValidateUpdate(_ context.Context, old, obj runtime.Object) (admission.Warnings, error) {
+	etcdadmConfig, ok := obj.(*EtcdadmConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected an EtcdadmConfig but got %T", obj)
+	}
+
+	oldConfig, ok := old.(*EtcdadmConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected an EtcdadmConfig but got %T", old)
+	}
+
+	etcdadmconfiglog.Info("validate update", "name", etcdadmConfig.Name)
+
+	return etcdadmConfig.Spec.warnings(field.NewPath("spec")), etcdadmConfig.validate(&oldConfig.Spec)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *EtcdadmConfig) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	etcdadmConfig, ok := obj.(*EtcdadmConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected an EtcdadmConfig but got %T", obj)
+	}
+
+	etcdadmconfiglog.Info("validate delete", "name", etcdadmConfig.Name)
+
+	// TODO(user): fill in your validation logic upon object deletion.
+	return nil, nil
+}
+
+func (r *EtcdadmConfig) validate(old *EtcdadmConfigSpec) error {
+	allErrs := r.Spec.validate(field.NewPath("spec"), old)
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("EtcdadmConfig").GroupKind(), r.Name, allErrs)
+}
+
+// validate checks the spec. On update, old is the spec being replaced, so that fields objects were created with before
+// they were validated do not block later updates.
+func (s *EtcdadmConfigSpec) validate(path *field.Path, old *EtcdadmConfigSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, s.validateBackup(path.Child("backup"))...)
+	allErrs = append(allErrs, s.validateRestoreFrom(path.Child("restoreFrom"))...)
+	allErrs = append(allErrs, s.validateAPIServerEtcdClientCertificate(path.Child("apiServerEtcdClientCertificate"))...)
+	allErrs = append(allErrs, s.validateBottlerocketSettings(path.Child("bottlerocketConfig", "settings"))...)
+	allErrs = append(allErrs, s.validateNetwork(path.Child("network"))...)
+	allErrs = append(allErrs, s.validateRegistryMirror(path.Child("registryMirror"))...)
+	allErrs = append(allErrs, s.validateCertBundles(path.Child("certBundles"), old)...)
+	allErrs = append(allErrs, s.validateKernel(path.Child("kernel"))...)
+	allErrs = append(allErrs, s.validateFormat(path, old)...)
+	return allErrs
+}
+
+// FormatValidator validates the fields of EtcdadmConfigSpecs whose support depends on their bootstrap data format.
+// The formats are registered by the packages rendering them, which set it with SetFormatValidator.
+type FormatValidator interface {
+	// ValidateFormat returns the reasons spec cannot be rendered in its format, with paths relative to the spec, and
+	// the names of the fields it sets that the format ignores.
+	ValidateFormat(spec EtcdadmConfigSpec) (field.ErrorList, []string)
+}
+
+var formatValidator FormatValidator
+
+// SetFormatValidator makes the webhooks validate specs against their format with validator. Specs are not validated
+// against their format until it is set.
+func SetFormatValidator(validator FormatValidator) {
+	formatValidator = validator
+}
+
+// validateFormat checks that the spec can be rendered in its format. Errors old already had are let through so that
+// objects created before the format was validated can still be updated.
+func (s *EtcdadmConfigSpec) validateFormat(path *field.Path, old *EtcdadmConfigSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if formatValidator == nil {
+		return allErrs
+	}
+	formatErrs, _ := formatValidator.ValidateFormat(*s)
+	var oldErrs field.ErrorList
+	if old != nil {
+		oldErrs, _ = formatValidator.ValidateFormat(*old)
+	}
+	for _, err := range formatErrs {
+		if slices.ContainsFunc(oldErrs, func(oldErr *field.Error) bool {
+			return oldErr.Type == err.Type && oldErr.Field == err.Field
+		}) {
+			continue
+		}
+		err.Field = path.String() + "." + err.Field
+		allErrs = append(allErrs, err)
+	}
+	return allErrs
+}
+
+// warnings returns the warnings about the fields of the spec its format ignores.
+func (s *EtcdadmConfigSpec) warnings(path *field.Path) admission.Warnings {
+	if formatValidator == nil {
+		return nil
+	}
+	_, ignored := formatValidator.ValidateFormat(*s)
+	format := s.Format
+	if format == "" {
+		format = CloudConfig
+	}
+	var warnings admission.Warnings
+	for _, name := range ignored {
+		warnings = append(warnings, fmt.Sprintf("%s is ignored with the %s format", path.Child(name), format))
+	}
+	return warnings
+}
+
+func (s *EtcdadmConfigSpec) validateBackup(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	backup := s.Backup
+	if backup == nil {
+		return allErrs
+	}
+
+	if backup.Retention < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("retention"), backup.Retention, "must be greater than or equal to 0"))
+	}
+	if backup.S3 != nil {
+		s3Path := path.Child("s3")
+		if backup.S3.Endpoint == "" {
+			allErrs = append(allErrs, field.Required(s3Path.Child("endpoint"), ""))
+		}
+		if backup.S3.Bucket == "" {
+			allErrs = append(allErrs, field.Required(s3Path.Child("bucket"), ""))
+		}
+		if backup.S3.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(s3Path.Child("credentialsSecretRef", "name"), ""))
+		}
+	}
+	return allErrs
+}
+
+func (s *EtcdadmConfigSpec) validateRestoreFrom(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	restore := s.RestoreFrom
+	if restore == nil {
+		return allErrs
+	}
+
+	sources := 0
+	if restore.URL != "" {
+		sources++
+		if restore.SHA256 == "" {
+			allErrs = append(allErrs, field.Required(path.Child("sha256"), "is required when downloading the snapshot from a url"))
+		}
+	}
+	if restore.SecretRef != nil {
+		sources++
+		if restore.SecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("secretRef", "name"), ""))
+		}
+	}
+	if restore.ConfigMapRef != nil {
+		sources++
+		if restore.ConfigMapRef.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("configMapRef", "name"), ""))
+		}
+	}
+	switch {
+	case sources == 0:
+		allErrs = append(allErrs, field.Required(path, "one of url, secretRef or configMapRef must be set"))
+	case sources > 1:
+		allErrs = append(allErrs, field.Forbidden(path, "only one of url, secretRef and configMapRef may be set"))
+	}
+
+	if restore.SHA256 != "" {
+		if checksum, err := hex.DecodeString(restore.SHA256); err != nil || len(checksum) != 32 {
+			allErrs = append(allErrs, field.Invalid(path.Child("sha256"), restore.SHA256, "must be a hex-encoded SHA-256 checksum"))
+		}
+	}
+	return allErrs
+}
+
+func (s *EtcdadmConfigSpec) validateAPIServerEtcdClientCertificate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	cert := s.APIServerEtcdClientCertificate
+	if cert == nil {
+		return allErrs
+	}
+
+	if cert.SecretName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(cert.SecretName) {
+			allErrs = append(allErrs, field.Invalid(path.Child("secretName"), cert.SecretName, msg))
+		}
+	}
+	if cert.Validity != nil && cert.Validity.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("validity"), cert.Validity.Duration.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func (s *EtcdadmConfigSpec) validateNetwork(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	network := s.Network
+	if network == nil {
+		return allErrs
+	}
+
+	for i, nameServer := range network.NameServers {
+		if net.ParseIP(nameServer) == nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("nameServers").Index(i), nameServer, "must be an IP address"))
+		}
+	}
+	for i, domain := range network.SearchDomains {
+		for _, msg := range validation.IsDNS1123Subdomain(domain) {
+			allErrs = append(allErrs, field.Invalid(path.Child("searchDomains").Index(i), domain, msg))
+		}
+	}
+	for i, host := range network.Hosts {
+		hostPath := path.Child("hosts").Index(i)
+		if net.ParseIP(host.IP) == nil {
+			allErrs = append(allErrs, field.Invalid(hostPath.Child("ip"), host.IP, "must be an IP address"))
+		}
+		if len(host.Hostnames) == 0 {
+			allErrs = append(allErrs, field.Required(hostPath.Child("hostnames"), ""))
+		}
+		for j, hostname := range host.Hostnames {
+			for _, msg := range validation.IsDNS1123Subdomain(hostname) {
+				allErrs = append(allErrs, field.Invalid(hostPath.Child("hostnames").Index(j), hostname, msg))
+			}
+		}
+	}
+	return allErrs
+}
+
+var (
+	// sysctlKey matches sysctl names, whose components are separated by '.' or '/'.
+	sysctlKey = regexp.MustCompile(`^[a-zA-Z0-9_-]+([./][a-zA-Z0-9_-]+)*$`)
+	// kernelParameterKey and kernelParameterValue match kernel command line parameters that need no quoting, neither in
+	// the bootloader configuration nor in the shell.
+	kernelParameterKey   = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+	kernelParameterValue = regexp.MustCompile(`^[a-zA-Z0-9_.,:/=+@%-]+$`)
+)
+
+func (s *EtcdadmConfigSpec) validateKernel(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	kernel := s.Kernel
+	if kernel == nil {
+		return allErrs
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(kernel.SysctlSettings)) {
+		keyPath := path.Child("sysctlSettings").Key(key)
+		if !sysctlKey.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "must be a sysctl name"))
+		}
+		if value := kernel.SysctlSettings[key]; strings.ContainsAny(value, "\r\n") {
+			allErrs = append(allErrs, field.Invalid(keyPath, value, "must not contain line breaks"))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(kernel.BootKernelParameters)) {
+		keyPath := path.Child("bootKernelParameters").Key(key)
+		if !kernelParameterKey.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "must only contain alphanumeric characters, '_', '.' and '-'"))
+		}
+		for i, value := range kernel.BootKernelParameters[key] {
+			if !kernelParameterValue.MatchString(value) {
+				allErrs = append(allErrs, field.Invalid(keyPath.Index(i), value, "must only contain alphanumeric characters and any of '_.,:/=+@%-'"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// certBundleName matches the names of cert bundles, which Bottlerocket uses as the key of their settings.pki entry.
+var certBundleName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// validateCertBundles checks the cert bundles for both formats, so that a bundle accepted for cloud-config machines is
+// also accepted when the machines are moved to Bottlerocket. Bundles that old already has are not checked again, as
+// they may predate these checks.
+func (s *EtcdadmConfigSpec) validateCertBundles(path *field.Path, old *EtcdadmConfigSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	var names []string
+	for i, bundle := range s.CertBundles {
+		if old != nil && slices.Contains(old.CertBundles, bundle) {
+			names = append(names, bundle.Name)
+			continue
+		}
+		bundlePath := path.Index(i)
+		switch {
+		case bundle.Name == "":
+			allErrs = append(allErrs, field.Required(bundlePath.Child("name"), ""))
+		case !certBundleName.MatchString(bundle.Name):
+			allErrs = append(allErrs, field.Invalid(bundlePath.Child("name"), bundle.Name, "must only contain alphanumeric characters, '_' and '-'"))
+		case strings.HasPrefix(bundle.Name, "registry-mirror-ca"):
+			allErrs = append(allErrs, field.Invalid(bundlePath.Child("name"), bundle.Name, "is reserved for the CA certificates of registry mirrors"))
+		case slices.Contains(names, bundle.Name):
+			allErrs = append(allErrs, field.Duplicate(bundlePath.Child("name"), bundle.Name))
+		}
+		names = append(names, bundle.Name)
+		if msg := validateCertificates(bundle.Data); msg != "" {
+			allErrs = append(allErrs, field.Invalid(bundlePath.Child("data"), "<certificates>", msg))
+		}
+	}
+	return allErrs
+}
+
+// validateCertificates returns why data is not a list of PEM encoded certificates, or an empty string if it is.
+func validateCertificates(data string) string {
+	rest := []byte(data)
+	var count int
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Sprintf("must only contain certificates, found a %s PEM block", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Sprintf("certificate %d cannot be parsed: %v", count+1, err)
+		}
+		count++
+	}
+	if count == 0 {
+		return "must contain at least one PEM encoded certificate"
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return "must only contain PEM encoded certificates"
+	}
+	return ""
+}
+
+// registryMirrorPathPrefix matches the path prefixes of registry mirrors.
+var registryMirrorPathPrefix = regexp.MustCompile(`^[a-zA-Z0-9._/-]*$`)
+
+func (s *EtcdadmConfigSpec) validateRegistryMirror(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	registryMirror := s.RegistryMirror
+	if registryMirror == nil {
+		return allErrs
+	}
+
+	var upstreams []string
+	if registryMirror.Endpoint != "" {
+		upstreams = append(upstreams, "public.ecr.aws")
+	}
+	for i, mirror := range registryMirror.Mirrors {
+		mirrorPath := path.Child("mirrors").Index(i)
+		for _, msg := range validateRegistryHost(mirror.Upstream) {
+			allErrs = append(allErrs, field.Invalid(mirrorPath.Child("upstream"), mirror.Upstream, msg))
+		}
+		if slices.Contains(upstreams, mirror.Upstream) {
+			allErrs = append(allErrs, field.Duplicate(mirrorPath.Child("upstream"), mirror.Upstream))
+		}
+		upstreams = append(upstreams, mirror.Upstream)
+		for _, msg := range validateRegistryHost(mirror.Endpoint) {
+			allErrs = append(allErrs, field.Invalid(mirrorPath.Child("endpoint"), mirror.Endpoint, msg))
+		}
+		if !registryMirrorPathPrefix.MatchString(mirror.PathPrefix) {
+			allErrs = append(allErrs, field.Invalid(mirrorPath.Child("pathPrefix"), mirror.PathPrefix, "must only contain alphanumeric characters, '.', '_', '-' and '/'"))
+		}
+	}
+
+	if ref := registryMirror.CredentialsRef; ref != nil {
+		refPath := path.Child("credentialsRef")
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ref.Name, msg))
+		}
+		for _, key := range []struct {
+			name  string
+			value string
+		}{
+			{name: "usernameKey", value: ref.UsernameKey},
+			{name: "passwordKey", value: ref.PasswordKey},
+		} {
+			if key.value == "" {
+				continue
+			}
+			for _, msg := range validation.IsConfigMapKey(key.value) {
+				allErrs = append(allErrs, field.Invalid(refPath.Child(key.name), key.value, msg))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateRegistryHost returns the reasons host is not a host name or IP address, optionally followed by a port.
+func validateRegistryHost(host string) []string {
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return []string{"port must be a number"}
+		}
+		if msgs := validation.IsValidPortNum(p); len(msgs) > 0 {
+			return msgs
+		}
+		host = h
+	}
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	return validation.IsDNS1123Subdomain(host)
+}
+
+// providerOwnedBottlerocketSettings are the Bottlerocket settings the bootstrap data relies on, which may not be
+// overridden through BottlerocketConfig.Settings.
+var providerOwnedBottlerocketSettings = [][]string{
+	{"host-containers", "kubeadm-bootstrap"},
+	{"host-containers", "etcd-backup"},
+	{"host-containers", "etcd-learner"},
+	{"bootstrap-containers", "etcd-learner"},
+	{"network", "hostname"},
+}
+
+func (s *EtcdadmConfigSpec) validateBottlerocketSettings(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if s.BottlerocketConfig == nil || s.BottlerocketConfig.Settings == nil {
+		return allErrs
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(s.BottlerocketConfig.Settings.Raw, &settings); err != nil {
+		return append(allErrs, field.Invalid(path, string(s.BottlerocketConfig.Settings.Raw), "must be a JSON object"))
+	}
+	for _, owned := range providerOwnedBottlerocketSettings {
+		if hasSetting(settings, owned) {
+			allErrs = append(allErrs, field.Forbidden(path.Child(owned[0], owned[1:]...), "is set by the provider"))
+		}
+	}
+	allErrs = append(allErrs, validateBottlerocketSettingValue(path, settings)...)
+	return allErrs
+}
+
+// hasSetting returns whether the setting at keys is set in settings.
+func hasSetting(settings map[string]interface{}, keys []string) bool {
+	for i, key := range keys {
+		value, ok := settings[key]
+		if !ok {
+			return false
+		}
+		if i == len(keys)-1 {
+			return true
+		}
+		if settings, ok = value.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return false
+}
+
+// validateBottlerocketSettingValue rejects null values, which TOML cannot represent.
+func validateBottlerocketSettingValue(path *field.Path, value interface{}) field.ErrorList {
+	var allErrs field.ErrorList
+	switch value := value.(type) {
+	case nil:
+		allErrs = append(allErrs, field.Invalid(path, nil, "must not be null"))
+	case []interface{}:
+		for i := range value {
+			allErrs = append(allErrs, validateBottlerocketSettingValue(path.Index(i), value[i])...)
+		}
+	case map[string]interface{}:
+		for k := range value {
+			allErrs = append(allErrs, validateBottlerocketSettingValue(path.Child(k), value[k])...)
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,183 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api/util/topology"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var etcdadmconfigtemplatelog = logf.Log.WithName("etcdadmconfigtemplate-resource")
+
+func (r *EtcdadmConfigTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(r).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-bootstrap-cluster-x-k8s-io-v1beta2-etcdadmconfigtemplate,mutating=true,failurePolicy=fail,groups=bootstrap.cluster.x-k8s.io,resources=etcdadmconfigtemplates,verbs=create;update,versions=v1beta2,name=metcdadmconfigtemplate.kb.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.CustomDefaulter = &EtcdadmConfigTemplate{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (r *EtcdadmConfigTemplate) Default(_ context.Context, obj runtime.Object) error {
+	template, ok := obj.(*EtcdadmConfigTemplate)
+	if !ok {
+		return fmt.Errorf("expected an EtcdadmConfigTemplate but got %T", obj)
+	}
+
+	etcdadmconfigtemplatelog.Info("default", "name", template.Name)
+
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-bootstrap-cluster-x-k8s-io-v1beta2-etcdadmconfigtemplate,mutating=false,failurePolicy=fail,groups=bootstrap.cluster.x-k8s.io,resources=etcdadmconfigtemplates,versions=v1beta2,name=vetcdadmconfigtemplate.kb.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.CustomValidator = &EtcdadmConfigTemplate{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *EtcdadmConfigTemplate) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	template, ok := obj.(*EtcdadmConfigTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected an EtcdadmConfigTemplate but got %T", obj)
+	}
+
+	etcdadmconfigtemplatelog.Info("validate create", "name", template.Name)
+
+	return template.Spec.Template.Spec.warnings(field.NewPath("spec", "template", "spec")), template.validate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *EtcdadmConfigTemplate) ValidateUpdate(ctx context.Context, oldRaw, newRaw runtime.Object) (admission.Warnings, error) {
+	template, ok := newRaw.(*EtcdadmConfigTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected an EtcdadmConfigTemplate but got %T", newRaw)
+	}
+	oldTemplate, ok := oldRaw.(*EtcdadmConfigTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected an EtcdadmConfigTemplate but got %T", oldRaw)
+	}
+
+	etcdadmconfigtemplatelog.Info("validate update", "name", template.Name)
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an admission.Request inside context: %v", err))
+	}
+
+	allErrs := template.Spec.Template.Spec.validate(field.NewPath("spec", "template", "spec"), &oldTemplate.Spec.Template.Spec)
+	// the topology controller dry-runs updates to find out whether a template has to be rotated
+	if !topology.IsDryRunRequest(req, template) {
+		immutableErrs, err := validateImmutableTemplateSpec(&oldTemplate.Spec.Template.Spec, &template.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		allErrs = append(allErrs, immutableErrs...)
+	}
+	warnings := template.Spec.Template.Spec.warnings(field.NewPath("spec", "template", "spec"))
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(GroupVersion.WithKind("EtcdadmConfigTemplate").GroupKind(), template.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *EtcdadmConfigTemplate) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	template, ok := obj.(*EtcdadmConfigTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected an EtcdadmConfigTemplate but got %T", obj)
+	}
+
+	etcdadmconfigtemplatelog.Info("validate delete", "name", template.Name)
+
+	return nil, nil
+}
+
+func (r *EtcdadmConfigTemplate) validate() error {
+	allErrs := r.Spec.Template.Spec.validate(field.NewPath("spec", "template", "spec"), nil)
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("EtcdadmConfigTemplate").GroupKind(), r.Name, allErrs)
+}
+
+// mutableTemplateSpecFields are the fields of EtcdadmConfigSpec the reconciler keeps applying after the bootstrap
+// data has been generated. Every other field is only read once to render the bootstrap data.
+var mutableTemplateSpecFields = []string{"apiServerEtcdClientCertificate"}
+
+// validateImmutableTemplateSpec forbids changes to the fields of a template spec that are baked into the bootstrap data,
+// new settings have to be rolled out through a new template.
+func validateImmutableTemplateSpec(oldSpec, newSpec *EtcdadmConfigSpec, path *field.Path) (field.ErrorList, error) {
+	oldFields, err := specFields(oldSpec)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := specFields(newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(oldFields)+len(newFields))
+	for name := range oldFields {
+		names = append(names, name)
+	}
+	for name := range newFields {
+		if _, ok := oldFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var allErrs field.ErrorList
+	for _, name := range names {
+		if slices.Contains(mutableTemplateSpecFields, name) {
+			continue
+		}
+		if !reflect.DeepEqual(oldFields[name], newFields[name]) {
+			allErrs = append(allErrs, field.Forbidden(path.Child(name), "field is immutable, create a new EtcdadmConfigTemplate instead"))
+		}
+	}
+	return allErrs, nil
+}
+
+func specFields(spec *EtcdadmConfigSpec) (map[string]interface{}, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,185 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestEtcdadmConfigTemplateCastFail(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// Create a different type that will cause the cast to fail
+	wrongType := &runtime.Unknown{}
+	template := &EtcdadmConfigTemplate{}
+
+	g.Expect(template.Default(context.TODO(), wrongType)).To(gomega.MatchError(gomega.ContainSubstring("expected an EtcdadmConfigTemplate")))
+	_, err := template.ValidateCreate(context.TODO(), wrongType)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("expected an EtcdadmConfigTemplate")))
+	_, err = template.ValidateUpdate(context.TODO(), &EtcdadmConfigTemplate{}, wrongType)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("expected an EtcdadmConfigTemplate")))
+	_, err = template.ValidateDelete(context.TODO(), wrongType)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("expected an EtcdadmConfigTemplate")))
+}
+
+func TestEtcdadmConfigTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    EtcdadmConfigSpec
+		wantErr string
+	}{
+		{
+			name: "valid spec",
+			spec: EtcdadmConfigSpec{
+				Format: CloudConfig,
+				Backup: &BackupConfiguration{Schedule: "hourly", Retention: 3},
+			},
+		},
+		{
+			name: "invalid backup",
+			spec: EtcdadmConfigSpec{
+				Backup: &BackupConfiguration{Retention: -1},
+			},
+			wantErr: "spec.template.spec.backup.retention: Invalid value",
+		},
+		{
+			name: "invalid restore",
+			spec: EtcdadmConfigSpec{
+				RestoreFrom: &RestoreConfiguration{},
+			},
+			wantErr: "spec.template.spec.restoreFrom: Required value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			template := &EtcdadmConfigTemplate{
+				Spec: EtcdadmConfigTemplateSpec{Template: EtcdadmConfigTemplateResource{Spec: tt.spec}},
+			}
+
+			_, createErr := template.ValidateCreate(context.TODO(), template)
+			_, updateErr := template.ValidateUpdate(admission.NewContextWithRequest(context.TODO(), admission.Request{}), template, template)
+			if tt.wantErr == "" {
+				g.Expect(createErr).NotTo(gomega.HaveOccurred())
+				g.Expect(updateErr).NotTo(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(createErr).To(gomega.MatchError(gomega.ContainSubstring(tt.wantErr)))
+			g.Expect(updateErr).To(gomega.MatchError(gomega.ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestEtcdadmConfigTemplateValidateUpdateImmutable(t *testing.T) {
+	oldSpec := EtcdadmConfigSpec{
+		Format:          CloudConfig,
+		CloudInitConfig: &CloudInitConfig{Version: "3.5.9"},
+		RegistryMirror:  &RegistryMirrorConfiguration{Endpoint: "mirror.example.com"},
+	}
+	tests := []struct {
+		name        string
+		update      func(spec *EtcdadmConfigSpec)
+		dryRun      bool
+		annotations map[string]string
+		wantErr     []string
+	}{
+		{
+			name:   "unchanged",
+			update: func(*EtcdadmConfigSpec) {},
+		},
+		{
+			name: "etcd version and registry mirror changed",
+			update: func(spec *EtcdadmConfigSpec) {
+				spec.CloudInitConfig.Version = "3.5.10"
+				spec.RegistryMirror = nil
+			},
+			wantErr: []string{"spec.template.spec.cloudInitConfig: Forbidden", "spec.template.spec.registryMirror: Forbidden"},
+		},
+		{
+			name: "proxy added",
+			update: func(spec *EtcdadmConfigSpec) {
+				spec.Proxy = &ProxyConfiguration{HTTPSProxy: "https://proxy.example.com"}
+			},
+			wantErr: []string{"spec.template.spec.proxy: Forbidden"},
+		},
+		{
+			name: "apiserver etcd client certificate changed",
+			update: func(spec *EtcdadmConfigSpec) {
+				spec.APIServerEtcdClientCertificate = &APIServerEtcdClientCertificate{CommonName: "apiserver"}
+			},
+		},
+		{
+			name: "topology controller dry run",
+			update: func(spec *EtcdadmConfigSpec) {
+				spec.CloudInitConfig.Version = "3.5.10"
+			},
+			dryRun:      true,
+			annotations: map[string]string{clusterv1.TopologyDryRunAnnotation: ""},
+		},
+		{
+			name: "dry run without the topology annotation",
+			update: func(spec *EtcdadmConfigSpec) {
+				spec.CloudInitConfig.Version = "3.5.10"
+			},
+			dryRun:  true,
+			wantErr: []string{"spec.template.spec.cloudInitConfig: Forbidden"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			oldTemplate := &EtcdadmConfigTemplate{
+				Spec: EtcdadmConfigTemplateSpec{Template: EtcdadmConfigTemplateResource{Spec: oldSpec}},
+			}
+			template := oldTemplate.DeepCopy()
+			template.Annotations = tt.annotations
+			tt.update(&template.Spec.Template.Spec)
+
+			ctx := admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{DryRun: ptr.To(tt.dryRun)},
+			})
+			_, err := template.ValidateUpdate(ctx, oldTemplate, template)
+			if len(tt.wantErr) == 0 {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).To(gomega.HaveOccurred())
+			for _, wantErr := range tt.wantErr {
+				g.Expect(err.Error()).To(gomega.ContainSubstring(wantErr))
+			}
+		})
+	}
+}
+
+func TestEtcdadmConfigTemplateValidateUpdateWithoutRequest(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	template := &EtcdadmConfigTemplate{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}}
+	_, err := template.ValidateUpdate(context.TODO(), template, template)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("expected an admission.Request inside context")))
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const redacted = "<redacted>"
+
+// secretEnvPattern matches the variables of environment files, such as the one of the etcd-backup host container,
+// holding credentials.
+var secretEnvPattern = regexp.MustCompile(`^(\s*(?:export\s+)?[A-Z0-9_]*(?:SECRET|PASSWORD|TOKEN|AUTH)[A-Z0-9_]*=).*$`)
+
+// privateKeyPattern matches the PEM private keys embedded in text, such as scripts.
+var privateKeyPattern = regexp.MustCompile(`-----BEGIN ([A-Z0-9 ]*PRIVATE KEY)-----[\s\S]*?-----END [A-Z0-9 ]*PRIVATE KEY-----`)
+
+// secretFields holds the keys of the registry credentials of Bottlerocket settings and docker configs, and of the
+// password hashes of cloud-config users. auth is the base64 encoded "username:password", set instead of password.
+var secretFields = map[string]bool{
+	"password": true,
+	"auth":     true,
+	"passwd":   true,
+}
+
+type inspectOptions struct {
+	showSecrets bool
+}
+
+func newInspectCommand() *cobra.Command {
+	opts := &inspectOptions{}
+	cmd := &cobra.Command{
+		Use:   "inspect FILE",
+		Short: "Decode the nested layers of bootstrap data for review",
+		Long: `Decode a Bottlerocket TOML or cloud-config bootstrap payload, read from FILE or from stdin with -, and print
+it as YAML with every nested layer decoded: the base64 user data of host and bootstrap containers, the base64 PEM of
+settings.pki and the files written by cloud-config. Certificates are shown with their subject, issuer and expiry.
+Private keys, registry passwords and auths, user password hashes and credentials in environment files are redacted
+unless --show-secrets is set.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspect(opts, args[0], cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().BoolVar(&opts.showSecrets, "show-secrets", false, "Print private keys and credentials instead of redacting them.")
+	return cmd
+}
+
+func runInspect(opts *inspectOptions, path string, in io.Reader, out io.Writer) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	inspector := &payloadInspector{showSecrets: opts.showSecrets}
+	decoded, err := inspector.inspectPayload(data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to inspect %s", path)
+	}
+	yamlData, err := yaml.Marshal(decoded)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the decoded payload")
+	}
+	_, err = out.Write(yamlData)
+	return err
+}
+
+// pemBlock describes a PEM block found in bootstrap data.
+type pemBlock struct {
+	Type      string `json:"type"`
+	Subject   string `json:"subject,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+	NotBefore string `json:"notBefore,omitempty"`
+	NotAfter  string `json:"notAfter,omitempty"`
+	IsCA      bool   `json:"isCA,omitempty"`
+	Data      string `json:"data,omitempty"`
+}
+
+// payloadInspector decodes the nested layers of bootstrap data.
+type payloadInspector struct {
+	showSecrets bool
+}
+
+// inspectPayload decodes a top level bootstrap payload, which is either cloud-config or Bottlerocket TOML.
+func (p *payloadInspector) inspectPayload(data []byte) (interface{}, error) {
+	if isCloudConfig(data) {
+		return p.inspectCloudConfig(data)
+	}
+	settings := map[string]interface{}{}
+	if err := toml.Unmarshal(data, &settings); err != nil {
+		return nil, errors.Wrap(err, "payload is neither cloud-config nor valid Bottlerocket TOML")
+	}
+	return p.walk(settings, nil)
+}
+
+func (p *payloadInspector) inspectCloudConfig(data []byte) (interface{}, error) {
+	cloudConfig := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &cloudConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to parse cloud-config")
+	}
+	return p.walk(cloudConfig, nil)
+}
+
+// walk decodes the values of v that hold a nested layer, recognized by the keys leading to them. path holds the keys
+// of the tables v is nested in; items of arrays share the path of the array.
+func (p *payloadInspector) walk(v interface{}, path []string) (interface{}, error) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		decoded := make(map[string]interface{}, len(value))
+		for key, field := range value {
+			d, err := p.walkField(value, key, field, path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode %s", key)
+			}
+			decoded[key] = d
+		}
+		return decoded, nil
+	case []map[string]interface{}:
+		decoded := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			d, err := p.walk(item, path)
+			if err != nil {
+				return nil, err
+			}
+			decoded = append(decoded, d)
+		}
+		return decoded, nil
+	case []interface{}:
+		decoded := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			d, err := p.walk(item, path)
+			if err != nil {
+				return nil, err
+			}
+			decoded = append(decoded, d)
+		}
+		return decoded, nil
+	}
+	return v, nil
+}
+
+func (p *payloadInspector) walkField(fields map[string]interface{}, key string, field interface{}, path []string) (interface{}, error) {
+	s, isString := field.(string)
+	if !isString {
+		return p.walk(field, append(path[:len(path):len(path)], key))
+	}
+	switch {
+	case secretFields[key] && !p.showSecrets:
+		return redacted, nil
+	case key == "user-data":
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode base64")
+		}
+		return p.decodeLayer(data)
+	case key == "data" && len(path) >= 2 && path[len(path)-2] == "pki":
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode base64")
+		}
+		return p.decodeLayer(data)
+	case key == "content" && len(path) >= 1 && path[len(path)-1] == "write_files":
+		data := []byte(s)
+		if encoding, _ := fields["encoding"].(string); encoding == "b64" || encoding == "base64" {
+			var err error
+			if data, err = base64.StdEncoding.DecodeString(s); err != nil {
+				return nil, errors.Wrap(err, "failed to decode base64")
+			}
+		}
+		return p.decodeLayer(data)
+	}
+	return s, nil
+}
+
+// decodeLayer decodes nested bootstrap data, which is either cloud-config, PEM, JSON or plain text.
+func (p *payloadInspector) decodeLayer(data []byte) (interface{}, error) {
+	switch {
+	case isCloudConfig(data):
+		return p.inspectCloudConfig(data)
+	case isPEM(data):
+		return p.decodePEM(data)
+	case json.Valid(data) && bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")):
+		object := map[string]interface{}{}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, errors.Wrap(err, "failed to parse JSON")
+		}
+		return p.walk(object, nil)
+	}
+	return p.redactText(string(data)), nil
+}
+
+// isPEM returns whether data only holds PEM blocks. Text mixing PEM blocks with other content, such as a script
+// embedding a certificate, is kept as text.
+func isPEM(data []byte) bool {
+	found := false
+	for {
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			return found
+		}
+		if !bytes.HasPrefix(data, []byte("-----BEGIN ")) {
+			return false
+		}
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			return false
+		}
+		found = true
+	}
+}
+
+func (p *payloadInspector) decodePEM(data []byte) ([]pemBlock, error) {
+	var blocks []pemBlock
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		decoded := pemBlock{Type: block.Type}
+		switch {
+		case block.Type == "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse certificate")
+			}
+			decoded.Subject = cert.Subject.String()
+			decoded.Issuer = cert.Issuer.String()
+			decoded.NotBefore = cert.NotBefore.UTC().Format(time.RFC3339)
+			decoded.NotAfter = cert.NotAfter.UTC().Format(time.RFC3339)
+			decoded.IsCA = cert.IsCA
+		case strings.Contains(block.Type, "PRIVATE KEY") && !p.showSecrets:
+			decoded.Data = redacted
+		default:
+			decoded.Data = string(pem.EncodeToMemory(block))
+		}
+		blocks = append(blocks, decoded)
+	}
+	return blocks, nil
+}
+
+// redactText redacts the private keys and the credentials of environment files in text.
+func (p *payloadInspector) redactText(text string) string {
+	if p.showSecrets {
+		return text
+	}
+	text = privateKeyPattern.ReplaceAllString(text, "-----BEGIN ${1}-----\n"+redacted+"\n-----END ${1}-----")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = secretEnvPattern.ReplaceAllString(line, "${1}"+redacted)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isCloudConfig returns whether data starts with one of the headers of cloud-config user data.
+func isCloudConfig(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return bytes.HasPrefix(data, []byte("#cloud-config")) || bytes.HasPrefix(data, []byte("## template: jinja"))
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	bootstrapv1alpha3 "github.com/aws/etcdadm-bootstrap-provider/api/v1alpha3"
+	bootstrapv1beta1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
+)
+
+const (
+	roleInit = "init"
+	roleJoin = "join"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = bootstrapv1alpha3.AddToScheme(scheme)
+	_ = bootstrapv1beta1.AddToScheme(scheme)
+	_ = etcdbootstrapv1.AddToScheme(scheme)
+}
+
+type renderOptions struct {
+	configFile  string
+	role        string
+	joinAddress string
+	caCertFile  string
+	caKeyFile   string
+	hostname    string
+	snapshot    string
+}
+
+func newRenderCommand() *cobra.Command {
+	opts := &renderOptions{}
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Print the bootstrap data the controller generates for an EtcdadmConfig",
+		Long: `Print the cloud-config or Bottlerocket bootstrap data the controller generates for an EtcdadmConfig,
+without a management cluster. The output only depends on the flags, so renders can be diffed, as long as
+the etcd CA is passed with --ca-cert and --ca-key; otherwise a new CA is generated on every run.
+
+Inputs the controller reads from the management cluster are not resolved, so the output differs from the
+controller's where the config depends on them: registry mirror and backup credentials are left empty, and
+proxy.autoNoProxy does not add the cluster networks to the NO_PROXY list. A snapshot restoreFrom references in
+a Secret or ConfigMap is read from the file passed with --restore-snapshot instead.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRender(opts, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().StringVarP(&opts.configFile, "config", "f", "", "Path to the EtcdadmConfig YAML, in any served version.")
+	cmd.Flags().StringVar(&opts.role, "role", roleInit, "Role of the machine in the etcd cluster, init or join.")
+	cmd.Flags().StringVar(&opts.joinAddress, "join-address", "", "Client URL of the etcd member to join, required with --role join.")
+	cmd.Flags().StringVar(&opts.caCertFile, "ca-cert", "", "Path to the PEM encoded etcd CA certificate.")
+	cmd.Flags().StringVar(&opts.caKeyFile, "ca-key", "", "Path to the PEM encoded etcd CA private key.")
+	cmd.Flags().StringVar(&opts.hostname, "hostname", "", "Name of the machine. Defaults to the name of the EtcdadmConfig.")
+	cmd.Flags().StringVar(&opts.snapshot, "restore-snapshot", "", "Path to the etcd snapshot restoreFrom references in a Secret or ConfigMap, only used with --role init.")
+	_ = cmd.MarkFlagRequired("config")
+	return cmd
+}
+
+func runRender(opts *renderOptions, out, errOut io.Writer) error {
+	if opts.role != roleInit && opts.role != roleJoin {
+		return errors.Errorf("invalid role %q, must be %s or %s", opts.role, roleInit, roleJoin)
+	}
+	if opts.role == roleJoin && opts.joinAddress == "" {
+		return errors.New("--join-address is required with --role join")
+	}
+
+	config, err := readEtcdadmConfig(opts.configFile)
+	if err != nil {
+		return err
+	}
+	hostname := opts.hostname
+	if hostname == "" {
+		hostname = config.Name
+	}
+
+	etcdCerts, err := etcdCACertificates(opts.caCertFile, opts.caKeyFile)
+	if err != nil {
+		return err
+	}
+	if opts.caCertFile == "" {
+		fmt.Fprintln(errOut, "Generated a new etcd CA, pass --ca-cert and --ca-key for a reproducible output")
+	}
+
+	log := funcr.New(func(_, args string) {
+		fmt.Fprintln(errOut, args)
+	}, funcr.Options{})
+
+	data, err := renderBootstrapData(config, opts, hostname, etcdCerts, log)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+func renderBootstrapData(config *etcdbootstrapv1.EtcdadmConfig, opts *renderOptions, hostname string, etcdCerts secret.Certificates, log logr.Logger) ([]byte, error) {
+	if opts.role == roleJoin {
+		joinInput := userdata.EtcdPlaneJoinInput{
+			BaseUserData: render.BaseUserData(config.Spec, hostname),
+			JoinAddress:  opts.joinAddress,
+			Certificates: etcdCerts,
+		}
+		data, err := render.Join(&joinInput, config.Spec, log)
+		return data, errors.Wrap(err, "failed to render join bootstrap data")
+	}
+	initInput := userdata.EtcdPlaneInput{
+		BaseUserData: render.BaseUserData(config.Spec, hostname),
+		Certificates: etcdCerts,
+	}
+	if restore := config.Spec.RestoreFrom; restore != nil && (restore.SecretRef != nil || restore.ConfigMapRef != nil) {
+		if opts.snapshot == "" {
+			return nil, errors.New("restoreFrom references a snapshot in the management cluster, pass it with --restore-snapshot")
+		}
+		snapshot, err := os.ReadFile(opts.snapshot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", opts.snapshot)
+		}
+		initInput.RestoreSnapshot = snapshot
+	}
+	data, err := render.Init(&initInput, config.Spec, log)
+	return data, errors.Wrap(err, "failed to render init bootstrap data")
+}
+
+// readEtcdadmConfig reads the EtcdadmConfig in path and converts it to the storage version.
+func readEtcdadmConfig(path string) (*etcdbootstrapv1.EtcdadmConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", path)
+	}
+	switch o := obj.(type) {
+	case *etcdbootstrapv1.EtcdadmConfig:
+		return o, nil
+	case conversion.Convertible:
+		config := &etcdbootstrapv1.EtcdadmConfig{}
+		if err := o.ConvertTo(config); err != nil {
+			return nil, errors.Wrapf(err, "failed to convert %s", path)
+		}
+		return config, nil
+	}
+	return nil, errors.Errorf("%s holds a %T, expected an EtcdadmConfig", path, obj)
+}
+
+// etcdCACertificates returns the etcd CA read from certFile and keyFile, or a new one if neither is set.
+func etcdCACertificates(certFile, keyFile string) (secret.Certificates, error) {
+	etcdCerts := render.EtcdCACertificates()
+	if certFile == "" && keyFile == "" {
+		if err := etcdCerts.Generate(); err != nil {
+			return nil, errors.Wrap(err, "failed to generate etcd CA")
+		}
+		return etcdCerts, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("--ca-cert and --ca-key must be set together")
+	}
+
+	cert, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", certFile)
+	}
+	if _, err := certs.DecodeCertPEM(cert); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode etcd CA certificate %s", certFile)
+	}
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", keyFile)
+	}
+	if _, err := certs.DecodePrivateKeyPEM(key); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode etcd CA key %s", keyFile)
+	}
+	etcdCerts.GetByPurpose(secret.ManagedExternalEtcdCA).KeyPair = &certs.KeyPair{Cert: cert, Key: key}
+	return etcdCerts, nil
+}
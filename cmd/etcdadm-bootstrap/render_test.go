@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
+)
+
+const cloudConfigEtcdadmConfig = `apiVersion: bootstrap.cluster.x-k8s.io/v1beta2
+kind: EtcdadmConfig
+metadata:
+  name: etcd-0
+spec:
+  format: cloud-config
+  etcdadmBuiltin: true
+  cloudInitConfig:
+    version: v3.5.9
+`
+
+const v1alpha3EtcdadmConfig = `apiVersion: bootstrap.cluster.x-k8s.io/v1alpha3
+kind: EtcdadmConfig
+metadata:
+  name: etcd-1
+spec:
+  cloudInitConfig:
+    version: v3.5.9
+`
+
+const restoreEtcdadmConfig = `apiVersion: bootstrap.cluster.x-k8s.io/v1beta2
+kind: EtcdadmConfig
+metadata:
+  name: etcd-0
+spec:
+  format: cloud-config
+  etcdadmBuiltin: true
+  cloudInitConfig:
+    version: v3.5.9
+  restoreFrom:
+    secretRef:
+      name: etcd-snapshot
+`
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	caCertFile, caKeyFile := writeEtcdCA(t, dir)
+	snapshotFile := filepath.Join(dir, "snapshot.db")
+	NewWithT(t).Expect(os.WriteFile(snapshotFile, []byte("etcd snapshot"), 0o600)).To(Succeed())
+
+	tests := []struct {
+		name         string
+		config       string
+		args         []string
+		wantContains []string
+		wantErr      string
+	}{
+		{
+			name:   "renders init bootstrap data",
+			config: cloudConfigEtcdadmConfig,
+			args:   []string{"--role", "init"},
+			wantContains: []string{
+				"#cloud-config",
+				"etcdadm init",
+				"--version v3.5.9",
+				"systemctl stop kubelet",
+			},
+		},
+		{
+			name:   "renders join bootstrap data",
+			config: cloudConfigEtcdadmConfig,
+			args:   []string{"--role", "join", "--join-address", "https://10.0.0.1:2379"},
+			wantContains: []string{
+				"etcdadm join https://10.0.0.1:2379",
+			},
+		},
+		{
+			name:         "converts older versions",
+			config:       v1alpha3EtcdadmConfig,
+			wantContains: []string{"mv etcdadm /usr/local/bin/etcdadm"},
+		},
+		{
+			name:         "reads the snapshot to restore from",
+			config:       restoreEtcdadmConfig,
+			args:         []string{"--restore-snapshot", snapshotFile},
+			wantContains: []string{base64.StdEncoding.EncodeToString([]byte("etcd snapshot"))},
+		},
+		{
+			name:    "requires the snapshot restoreFrom references",
+			config:  restoreEtcdadmConfig,
+			wantErr: "pass it with --restore-snapshot",
+		},
+		{
+			name:    "requires a join address to join",
+			config:  cloudConfigEtcdadmConfig,
+			args:    []string{"--role", "join"},
+			wantErr: "--join-address is required with --role join",
+		},
+		{
+			name:    "rejects unknown roles",
+			config:  cloudConfigEtcdadmConfig,
+			args:    []string{"--role", "leave"},
+			wantErr: `invalid role "leave"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			g.Expect(os.WriteFile(configFile, []byte(tt.config), 0o600)).To(Succeed())
+			args := append([]string{"render", "-f", configFile, "--ca-cert", caCertFile, "--ca-key", caKeyFile}, tt.args...)
+
+			out, err := execute(args...)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			for _, want := range tt.wantContains {
+				g.Expect(out).To(ContainSubstring(want))
+			}
+
+			again, err := execute(args...)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(again).To(Equal(out))
+		})
+	}
+}
+
+func TestRenderCAFlags(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	caCertFile, _ := writeEtcdCA(t, dir)
+	configFile := filepath.Join(dir, "config.yaml")
+	g.Expect(os.WriteFile(configFile, []byte(cloudConfigEtcdadmConfig), 0o600)).To(Succeed())
+
+	_, err := execute("render", "-f", configFile, "--ca-cert", caCertFile)
+	g.Expect(err).To(MatchError(ContainSubstring("--ca-cert and --ca-key must be set together")))
+
+	out, err := execute("render", "-f", configFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(ContainSubstring("BEGIN CERTIFICATE"))
+}
+
+func execute(args ...string) (string, error) {
+	cmd := newRootCommand()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func writeEtcdCA(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	g := NewWithT(t)
+
+	etcdCerts := render.EtcdCACertificates()
+	g.Expect(etcdCerts.Generate()).To(Succeed())
+	ca := etcdCerts.GetByPurpose(secret.ManagedExternalEtcdCA)
+	certFile := filepath.Join(dir, "ca.crt")
+	keyFile := filepath.Join(dir, "ca.key")
+	g.Expect(os.WriteFile(certFile, ca.KeyPair.Cert, 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(keyFile, ca.KeyPair.Key, 0o600)).To(Succeed())
+	return certFile, keyFile
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/internal/etcd"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultAPIServerEtcdClientCommonName = "kube-apiserver-etcd-client"
+	defaultAPIServerEtcdClientValidity   = 365 * 24 * time.Hour
+)
+
+// apiServerEtcdClientSecretName returns the name of the Secret holding the kube-apiserver etcd client
+// certificate of cluster.
+func apiServerEtcdClientSecretName(cluster *clusterv1.Cluster, config *etcdbootstrapv1.APIServerEtcdClientCertificate) string {
+	if config.SecretName != "" {
+		return config.SecretName
+	}
+	return secret.Name(cluster.Name, secret.APIServerEtcdClient)
+}
+
+// renewAPIServerEtcdClientCertificate looks up the etcd CA of the cluster and makes sure the kube-apiserver
+// etcd client certificate is still valid for long enough. It returns the time left until the next renewal.
+func (r *EtcdadmConfigReconciler) renewAPIServerEtcdClientCertificate(ctx context.Context, scope *Scope) (time.Duration, error) {
+	if scope.Config.Spec.APIServerEtcdClientCertificate == nil {
+		return 0, nil
+	}
+	etcdCerts := render.EtcdCACertificates()
+	if err := etcdCerts.Lookup(ctx, r.Client, client.ObjectKeyFromObject(scope.Cluster)); err != nil {
+		return 0, errors.Wrap(err, "failed doing a lookup for the etcd CA")
+	}
+	return r.reconcileAPIServerEtcdClientCertificate(ctx, scope, etcdCerts)
+}
+
+// reconcileAPIServerEtcdClientCertificate issues the kube-apiserver etcd client certificate of the cluster from
+// the etcd CA in etcdCerts, unless the existing one is signed by that CA and not due for renewal yet.
+// It returns the time left until the next renewal.
+func (r *EtcdadmConfigReconciler) reconcileAPIServerEtcdClientCertificate(ctx context.Context, scope *Scope, etcdCerts secret.Certificates) (time.Duration, error) {
+	config := scope.Config.Spec.APIServerEtcdClientCertificate
+	if config == nil {
+		return 0, nil
+	}
+	ca := etcdCerts.GetByPurpose(secret.ManagedExternalEtcdCA)
+	if ca == nil || ca.KeyPair == nil {
+		return 0, errEtcdCANotFound
+	}
+
+	commonName := config.CommonName
+	if commonName == "" {
+		commonName = defaultAPIServerEtcdClientCommonName
+	}
+	validity := defaultAPIServerEtcdClientValidity
+	if config.Validity != nil {
+		validity = config.Validity.Duration
+	}
+	renewBefore := validity / 3
+
+	key := client.ObjectKey{Namespace: scope.Cluster.Namespace, Name: apiServerEtcdClientSecretName(scope.Cluster, config)}
+	existing := &corev1.Secret{}
+	if err := r.Client.Get(ctx, key, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, errors.Wrapf(err, "failed to retrieve Secret %q", key)
+		}
+		existing = nil
+	}
+	if existing != nil {
+		if expiry, ok := apiServerEtcdClientCertificateExpiry(existing, ca.KeyPair.Cert, commonName); ok {
+			if renewIn := time.Until(expiry.Add(-renewBefore)); renewIn > 0 {
+				markAPIServerEtcdClientCertificateAvailable(scope.Config)
+				return renewIn, nil
+			}
+		}
+		// the Secret may hold a certificate managed by someone else, which must not be replaced
+		if _, ok := existing.Labels[etcdbootstrapv1.APIServerEtcdClientCertificateLabel]; !ok {
+			message := "Secret " + key.Name + " was not created by the controller, its certificate is not renewed"
+			conditions.Set(scope.Config, metav1.Condition{
+				Type:    etcdbootstrapv1.APIServerEtcdClientCertificateAvailableCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  etcdbootstrapv1.APIServerEtcdClientSecretNotManagedReason,
+				Message: message,
+			})
+			v1beta1conditions.MarkFalse(scope.Config, etcdbootstrapv1.APIServerEtcdClientCertificateAvailableV1Beta1Condition, etcdbootstrapv1.APIServerEtcdClientSecretNotManagedReason, clusterv1.ConditionSeverityWarning, "%s", message)
+			scope.Logger.Info("Not renewing kube-apiserver etcd client certificate of a Secret the controller did not create", "secret", key.Name)
+			return 0, nil
+		}
+	}
+
+	certPEM, keyPEM, err := etcd.NewClientCertificate(ca.KeyPair.Cert, ca.KeyPair.Key, commonName, validity)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to issue kube-apiserver etcd client certificate")
+	}
+	data := map[string][]byte{
+		secret.TLSCrtDataName: certPEM,
+		secret.TLSKeyDataName: keyPEM,
+	}
+
+	if existing != nil {
+		existing.Data = data
+		if err := r.Client.Update(ctx, existing); err != nil {
+			return 0, errors.Wrapf(err, "failed to update Secret %q", key)
+		}
+		scope.Logger.Info("Renewed kube-apiserver etcd client certificate", "secret", key.Name)
+		markAPIServerEtcdClientCertificateAvailable(scope.Config)
+		return validity - renewBefore, nil
+	}
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:                          scope.Cluster.Name,
+				etcdbootstrapv1.APIServerEtcdClientCertificateLabel: "",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       scope.Cluster.Name,
+					UID:        scope.Cluster.UID,
+				},
+			},
+		},
+		Data: data,
+		Type: clusterv1.ClusterSecretType,
+	}
+	if err := r.Client.Create(ctx, s); err != nil {
+		return 0, errors.Wrapf(err, "failed to create Secret %q", key)
+	}
+	scope.Logger.Info("Issued kube-apiserver etcd client certificate", "secret", key.Name)
+	markAPIServerEtcdClientCertificateAvailable(scope.Config)
+	return validity - renewBefore, nil
+}
+
+// markAPIServerEtcdClientCertificateAvailable sets the APIServerEtcdClientCertificateAvailable condition of config to
+// true, along with its deprecated v1beta1 counterpart.
+func markAPIServerEtcdClientCertificateAvailable(config *etcdbootstrapv1.EtcdadmConfig) {
+	conditions.Set(config, metav1.Condition{
+		Type:   etcdbootstrapv1.APIServerEtcdClientCertificateAvailableCondition,
+		Status: metav1.ConditionTrue,
+		Reason: etcdbootstrapv1.APIServerEtcdClientCertificateAvailableReason,
+	})
+	v1beta1conditions.MarkTrue(config, etcdbootstrapv1.APIServerEtcdClientCertificateAvailableV1Beta1Condition)
+}
+
+// apiServerEtcdClientCertificateExpiry returns when the certificate stored in s expires, provided it is a
+// usable certificate for commonName signed by the CA in caCertPEM.
+func apiServerEtcdClientCertificateExpiry(s *corev1.Secret, caCertPEM []byte, commonName string) (time.Time, bool) {
+	if len(s.Data[secret.TLSKeyDataName]) == 0 {
+		return time.Time{}, false
+	}
+	cert, err := certs.DecodeCertPEM(s.Data[secret.TLSCrtDataName])
+	if err != nil || cert == nil {
+		return time.Time{}, false
+	}
+	caCert, err := certs.DecodeCertPEM(caCertPEM)
+	if err != nil || caCert == nil {
+		return time.Time{}, false
+	}
+	if cert.Subject.CommonName != commonName || cert.CheckSignatureFrom(caCert) != nil {
+		return time.Time{}, false
+	}
+	if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != x509.ExtKeyUsageClientAuth {
+		return time.Time{}, false
+	}
+	return cert.NotAfter, true
+}
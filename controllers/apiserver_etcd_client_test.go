@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/internal/etcd"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestEtcdadmConfigReconciler_APIServerEtcdClientCertificate(t *testing.T) {
+	validity := 90 * 24 * time.Hour
+	tests := []struct {
+		name           string
+		certificate    *etcdbootstrapv1.APIServerEtcdClientCertificate
+		existing       func(g *WithT, ca *secret.Certificate) map[string][]byte
+		wantSecretName string
+		wantCommonName string
+		wantValidity   time.Duration
+		wantRenewed    bool
+		// unmanaged leaves the label of the controller off the existing Secret
+		unmanaged bool
+	}{
+		{
+			name:           "issues a certificate with the defaults",
+			certificate:    &etcdbootstrapv1.APIServerEtcdClientCertificate{},
+			wantSecretName: "external-etcd-cluster-apiserver-etcd-client",
+			wantCommonName: defaultAPIServerEtcdClientCommonName,
+			wantValidity:   defaultAPIServerEtcdClientValidity,
+			wantRenewed:    true,
+		},
+		{
+			name: "issues a certificate with a custom name, common name and validity",
+			certificate: &etcdbootstrapv1.APIServerEtcdClientCertificate{
+				SecretName: "apiserver-etcd",
+				CommonName: "apiserver",
+				Validity:   &metav1.Duration{Duration: validity},
+			},
+			wantSecretName: "apiserver-etcd",
+			wantCommonName: "apiserver",
+			wantValidity:   validity,
+			wantRenewed:    true,
+		},
+		{
+			name:        "keeps a certificate that is not due for renewal",
+			certificate: &etcdbootstrapv1.APIServerEtcdClientCertificate{Validity: &metav1.Duration{Duration: validity}},
+			existing: func(g *WithT, ca *secret.Certificate) map[string][]byte {
+				return newAPIServerEtcdClientData(g, ca, defaultAPIServerEtcdClientCommonName, validity)
+			},
+			wantSecretName: "external-etcd-cluster-apiserver-etcd-client",
+			wantCommonName: defaultAPIServerEtcdClientCommonName,
+			wantValidity:   validity,
+		},
+		{
+			name:        "renews a certificate close to its expiry",
+			certificate: &etcdbootstrapv1.APIServerEtcdClientCertificate{Validity: &metav1.Duration{Duration: validity}},
+			existing: func(g *WithT, ca *secret.Certificate) map[string][]byte {
+				return newAPIServerEtcdClientData(g, ca, defaultAPIServerEtcdClientCommonName, validity/4)
+			},
+			wantSecretName: "external-etcd-cluster-apiserver-etcd-client",
+			wantCommonName: defaultAPIServerEtcdClientCommonName,
+			wantValidity:   validity,
+			wantRenewed:    true,
+		},
+		{
+			name:        "renews a certificate signed by another CA",
+			certificate: &etcdbootstrapv1.APIServerEtcdClientCertificate{Validity: &metav1.Duration{Duration: validity}},
+			existing: func(g *WithT, _ *secret.Certificate) map[string][]byte {
+				other := &secret.Certificate{Purpose: secret.ManagedExternalEtcdCA}
+				g.Expect(other.Generate()).To(Succeed())
+				return newAPIServerEtcdClientData(g, other, defaultAPIServerEtcdClientCommonName, validity)
+			},
+			wantSecretName: "external-etcd-cluster-apiserver-etcd-client",
+			wantCommonName: defaultAPIServerEtcdClientCommonName,
+			wantValidity:   validity,
+			wantRenewed:    true,
+		},
+		{
+			name:        "leaves a Secret it did not create",
+			certificate: &etcdbootstrapv1.APIServerEtcdClientCertificate{Validity: &metav1.Duration{Duration: validity}},
+			existing: func(g *WithT, _ *secret.Certificate) map[string][]byte {
+				other := &secret.Certificate{Purpose: secret.ManagedExternalEtcdCA}
+				g.Expect(other.Generate()).To(Succeed())
+				return newAPIServerEtcdClientData(g, other, "external-client", validity)
+			},
+			wantSecretName: "external-etcd-cluster-apiserver-etcd-client",
+			unmanaged:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := newCluster("external-etcd-cluster")
+			machine := newMachine(cluster, "machine")
+			config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+			config.Spec.APIServerEtcdClientCertificate = tt.certificate
+			config.Status.Initialization.DataSecretCreated = ptr.To(true)
+
+			etcdCACerts := render.EtcdCACertificates()
+			g.Expect(etcdCACerts.Generate()).To(Succeed())
+			ca := etcdCACerts.GetByPurpose(secret.ManagedExternalEtcdCA)
+			etcdCASecret := ca.AsSecret(client.ObjectKeyFromObject(cluster), *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
+
+			objects := []client.Object{cluster, machine, config, etcdCASecret}
+			var existingData map[string][]byte
+			if tt.existing != nil {
+				existingData = tt.existing(g, ca)
+				existing := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: tt.wantSecretName},
+					Data:       existingData,
+					Type:       clusterv1.ClusterSecretType,
+				}
+				if !tt.unmanaged {
+					existing.Labels = map[string]string{etcdbootstrapv1.APIServerEtcdClientCertificateLabel: ""}
+				}
+				objects = append(objects, existing)
+			}
+			myclient := fake.NewClientBuilder().
+				WithScheme(setupScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+				Build()
+			k := &EtcdadmConfigReconciler{
+				Log:             log.Log,
+				Client:          myclient,
+				EtcdadmInitLock: &etcdInitLocker{},
+			}
+
+			result, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+			clientSecret := &corev1.Secret{}
+			g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: tt.wantSecretName}, clientSecret)).To(Succeed())
+			if tt.unmanaged {
+				g.Expect(result.RequeueAfter).To(BeZero())
+				g.Expect(clientSecret.Data).To(Equal(existingData))
+				condition := conditions.Get(config, etcdbootstrapv1.APIServerEtcdClientCertificateAvailableCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal(etcdbootstrapv1.APIServerEtcdClientSecretNotManagedReason))
+				return
+			}
+			g.Expect(conditions.IsTrue(config, etcdbootstrapv1.APIServerEtcdClientCertificateAvailableCondition)).To(BeTrue())
+			g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			g.Expect(result.RequeueAfter).To(BeNumerically("<=", tt.wantValidity-tt.wantValidity/3))
+			g.Expect(clientSecret.Type).To(Equal(clusterv1.ClusterSecretType))
+			g.Expect(clientSecret.Labels).To(HaveKey(etcdbootstrapv1.APIServerEtcdClientCertificateLabel))
+			g.Expect(clientSecret.Data).To(HaveKey(secret.TLSKeyDataName))
+			if !tt.wantRenewed {
+				g.Expect(clientSecret.Data).To(Equal(existingData))
+				return
+			}
+			g.Expect(clientSecret.Data[secret.TLSCrtDataName]).NotTo(Equal(existingData[secret.TLSCrtDataName]))
+
+			cert, err := certs.DecodeCertPEM(clientSecret.Data[secret.TLSCrtDataName])
+			g.Expect(err).NotTo(HaveOccurred())
+			caCert, err := certs.DecodeCertPEM(ca.KeyPair.Cert)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cert.CheckSignatureFrom(caCert)).To(Succeed())
+			g.Expect(cert.Subject.CommonName).To(Equal(tt.wantCommonName))
+			g.Expect(cert.ExtKeyUsage).To(ConsistOf(x509.ExtKeyUsageClientAuth))
+			g.Expect(cert.NotAfter).To(BeTemporally("~", time.Now().Add(tt.wantValidity), time.Minute))
+		})
+	}
+}
+
+func TestEtcdadmConfigReconciler_APIServerEtcdClientCertificateNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Status.Initialization.DataSecretCreated = ptr.To(true)
+
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, machine, config).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+
+	result, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	err = myclient.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: "external-etcd-cluster-apiserver-etcd-client"}, &corev1.Secret{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func newAPIServerEtcdClientData(g *WithT, ca *secret.Certificate, commonName string, validity time.Duration) map[string][]byte {
+	cert, key, err := etcd.NewClientCertificate(ca.KeyPair.Cert, ca.KeyPair.Key, commonName, validity)
+	g.Expect(err).NotTo(HaveOccurred())
+	return map[string][]byte{
+		secret.TLSCrtDataName: cert,
+		secret.TLSKeyDataName: key,
+	}
+}
@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/internal/etcd"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultHealthCheckInterval is the default interval between two health checks of an etcd member.
+	DefaultHealthCheckInterval = time.Minute
+
+	etcdHealthCheckTimeout = 15 * time.Second
+	// etcdHealthClientCertRenewBefore is how long before it expires the client certificate of the health checks is
+	// replaced.
+	etcdHealthClientCertRenewBefore = 15 * time.Minute
+	etcdHealthSummaryKey            = "summary"
+	etcdHealthMemberPrefix          = "member."
+)
+
+// etcdMemberHealth is the health of one member as stored in the etcd health ConfigMap of the cluster.
+type etcdMemberHealth struct {
+	Machine   string      `json:"machine"`
+	Endpoint  string      `json:"endpoint,omitempty"`
+	MemberID  string      `json:"memberID,omitempty"`
+	Healthy   bool        `json:"healthy"`
+	Leader    bool        `json:"leader"`
+	DBSize    int64       `json:"dbSize"`
+	Errors    []string    `json:"errors,omitempty"`
+	LastCheck metav1.Time `json:"lastCheck"`
+}
+
+// etcdHealthSummary sums up the health of all members of the cluster.
+type etcdHealthSummary struct {
+	Members        int    `json:"members"`
+	HealthyMembers int    `json:"healthyMembers"`
+	Leader         string `json:"leader,omitempty"`
+}
+
+// etcdClientTLSConfigCache holds the TLS configs of the health checks per cluster, so that a client certificate is
+// not signed for every check. The zero value is ready to use.
+type etcdClientTLSConfigCache struct {
+	mu      sync.Mutex
+	configs map[client.ObjectKey]*tls.Config
+}
+
+// get returns the TLS config cached for cluster, unless its client certificate is about to expire.
+func (c *etcdClientTLSConfigCache) get(cluster client.ObjectKey, now time.Time) *tls.Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tlsConfig, ok := c.configs[cluster]
+	if !ok || now.Add(etcdHealthClientCertRenewBefore).After(tlsConfig.Certificates[0].Leaf.NotAfter) {
+		return nil
+	}
+	return tlsConfig
+}
+
+// set caches tlsConfig for cluster, and drops the TLS configs whose client certificate has expired.
+func (c *etcdClientTLSConfigCache) set(cluster client.ObjectKey, tlsConfig *tls.Config, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.configs == nil {
+		c.configs = map[client.ObjectKey]*tls.Config{}
+	}
+	maps.DeleteFunc(c.configs, func(_ client.ObjectKey, cached *tls.Config) bool {
+		return now.After(cached.Certificates[0].Leaf.NotAfter)
+	})
+	c.configs[cluster] = tlsConfig
+}
+
+// forget drops the TLS config cached for cluster, so that the next check reads the etcd CA again.
+func (c *etcdClientTLSConfigCache) forget(cluster client.ObjectKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.configs, cluster)
+}
+
+// etcdHealthConfigMapName returns the name of the ConfigMap holding the etcd health of cluster.
+func etcdHealthConfigMapName(cluster *clusterv1.Cluster) string {
+	return fmt.Sprintf("%s-etcd-health", cluster.Name)
+}
+
+// reconcileHealth checks the health of the etcd member of the Machine, reports it in the EtcdMemberHealthy
+// condition and the etcd health ConfigMap of the cluster, and schedules the next check.
+func (r *EtcdadmConfigReconciler) reconcileHealth(ctx context.Context, scope *Scope) (ctrl.Result, error) {
+	if r.HealthCheckInterval <= 0 {
+		return ctrl.Result{}, nil
+	}
+
+	health := r.checkMemberHealth(ctx, scope)
+	if err := r.updateEtcdHealthConfigMap(ctx, scope.Cluster, health); err != nil {
+		scope.Logger.Error(err, "Failed to update etcd health ConfigMap")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.HealthCheckInterval}, nil
+}
+
+func (r *EtcdadmConfigReconciler) checkMemberHealth(ctx context.Context, scope *Scope) etcdMemberHealth {
+	health := etcdMemberHealth{
+		Machine:   scope.Machine.Name,
+		LastCheck: metav1.Now(),
+	}
+
+	endpoints := machineEndpoints(scope.Machine)
+	if len(endpoints) == 0 {
+		markEtcdMemberNotHealthy(scope.Config, etcdbootstrapv1.WaitingForMachineAddressReason, clusterv1.ConditionSeverityInfo, "")
+		return health
+	}
+	health.Endpoint = endpoints[0]
+
+	clusterKey := client.ObjectKeyFromObject(scope.Cluster)
+	tlsConfig := r.healthTLSConfigs.get(clusterKey, health.LastCheck.Time)
+	if tlsConfig == nil {
+		var err error
+		if tlsConfig, err = r.etcdClientTLSConfig(ctx, scope.Cluster); err != nil {
+			health.Errors = []string{err.Error()}
+			markEtcdMemberNotHealthy(scope.Config, etcdbootstrapv1.EtcdMemberUnreachableReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return health
+		}
+		r.healthTLSConfigs.set(clusterKey, tlsConfig, health.LastCheck.Time)
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, etcdHealthCheckTimeout)
+	defer cancel()
+	member, err := etcd.CheckMember(checkCtx, health.Endpoint, tlsConfig)
+	if err != nil {
+		// The etcd CA may have been rotated, read it again on the next check.
+		r.healthTLSConfigs.forget(clusterKey)
+		health.Errors = []string{err.Error()}
+		markEtcdMemberNotHealthy(scope.Config, etcdbootstrapv1.EtcdMemberUnreachableReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return health
+	}
+
+	health.MemberID = fmt.Sprintf("%x", member.ID)
+	health.Healthy = member.Healthy
+	health.Leader = member.IsLeader
+	health.DBSize = member.DBSize
+	health.Errors = member.Errors
+	if !member.Healthy {
+		markEtcdMemberNotHealthy(scope.Config, etcdbootstrapv1.EtcdMemberUnhealthyReason, clusterv1.ConditionSeverityWarning, strings.Join(member.Errors, "; "))
+		return health
+	}
+	conditions.Set(scope.Config, metav1.Condition{
+		Type:   etcdbootstrapv1.EtcdMemberHealthyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: etcdbootstrapv1.EtcdMemberHealthyReason,
+	})
+	v1beta1conditions.MarkTrue(scope.Config, etcdbootstrapv1.EtcdMemberHealthyV1Beta1Condition)
+	return health
+}
+
+// markEtcdMemberNotHealthy sets the EtcdMemberHealthy condition of config to false, along with its deprecated
+// v1beta1 counterpart.
+func markEtcdMemberNotHealthy(config *etcdbootstrapv1.EtcdadmConfig, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.Set(config, metav1.Condition{
+		Type:    etcdbootstrapv1.EtcdMemberHealthyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	v1beta1conditions.MarkFalse(config, etcdbootstrapv1.EtcdMemberHealthyV1Beta1Condition, reason, severity, "%s", message)
+}
+
+// updateEtcdHealthConfigMap records health in the etcd health ConfigMap of the cluster, drops the entries
+// of machines that are gone and recomputes the summary. The ConfigMap is left untouched when only the time of the
+// check changed, and the update is retried when another member updated it concurrently.
+func (r *EtcdadmConfigReconciler) updateEtcdHealthConfigMap(ctx context.Context, cluster *clusterv1.Cluster, health etcdMemberHealth) error {
+	machines, err := r.etcdMachines(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		return r.writeEtcdHealthConfigMap(ctx, cluster, machines, health)
+	})
+}
+
+func (r *EtcdadmConfigReconciler) writeEtcdHealthConfigMap(ctx context.Context, cluster *clusterv1.Cluster, machines []clusterv1.Machine, health etcdMemberHealth) error {
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: etcdHealthConfigMapName(cluster)}
+	exists := true
+	if err := r.Client.Get(ctx, key, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to retrieve ConfigMap %q", key)
+		}
+		exists = false
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel: cluster.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Cluster",
+						Name:       cluster.Name,
+						UID:        cluster.UID,
+					},
+				},
+			},
+		}
+	}
+
+	entry, err := json.Marshal(health)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode etcd member health")
+	}
+	memberKey := etcdHealthMemberPrefix + health.Machine
+	data := map[string]string{
+		memberKey: string(entry),
+	}
+	if previous, ok := configMap.Data[memberKey]; ok && sameEtcdMemberHealth(previous, health) {
+		data[memberKey] = previous
+	}
+	summary := etcdHealthSummary{}
+	for _, m := range machines {
+		memberKey := etcdHealthMemberPrefix + m.Name
+		if _, ok := data[memberKey]; !ok {
+			previous, ok := configMap.Data[memberKey]
+			if !ok {
+				continue
+			}
+			data[memberKey] = previous
+		}
+		member := etcdMemberHealth{}
+		if err := json.Unmarshal([]byte(data[memberKey]), &member); err != nil {
+			continue
+		}
+		summary.Members++
+		if member.Healthy {
+			summary.HealthyMembers++
+		}
+		if member.Leader {
+			summary.Leader = member.Machine
+		}
+	}
+	encodedSummary, err := json.Marshal(summary)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode etcd health summary")
+	}
+	data[etcdHealthSummaryKey] = string(encodedSummary)
+
+	if !exists {
+		configMap.Data = data
+		if err := r.Client.Create(ctx, configMap); err != nil {
+			return errors.Wrapf(err, "failed to create ConfigMap %q", key)
+		}
+		return nil
+	}
+	if maps.Equal(configMap.Data, data) {
+		return nil
+	}
+	configMap.Data = data
+	if err := r.Client.Update(ctx, configMap); err != nil {
+		return errors.Wrapf(err, "failed to update ConfigMap %q", key)
+	}
+	return nil
+}
+
+// sameEtcdMemberHealth returns whether the encoded entry records health, apart from the time of the check.
+func sameEtcdMemberHealth(entry string, health etcdMemberHealth) bool {
+	previous := etcdMemberHealth{}
+	if err := json.Unmarshal([]byte(entry), &previous); err != nil {
+		return false
+	}
+	previous.LastCheck = health.LastCheck
+	return equality.Semantic.DeepEqual(previous, health)
+}
@@ -0,0 +1,240 @@
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"testing"
+	"time"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestEtcdadmConfigReconciler_ReconcileHealth(t *testing.T) {
+	otherHealth, _ := json.Marshal(etcdMemberHealth{Machine: "other-machine", Healthy: true, Leader: true})
+	tests := []struct {
+		name           string
+		addresses      clusterv1.MachineAddresses
+		existingData   map[string]string
+		wantReason     string
+		wantMemberKeys []string
+		wantSummary    etcdHealthSummary
+	}{
+		{
+			name:           "machine has no address yet",
+			wantReason:     etcdbootstrapv1.WaitingForMachineAddressReason,
+			wantMemberKeys: []string{"member.machine"},
+			wantSummary:    etcdHealthSummary{Members: 1},
+		},
+		{
+			name:      "etcd CA is missing and stale members are dropped",
+			addresses: clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}},
+			existingData: map[string]string{
+				"member.other-machine": string(otherHealth),
+				"member.gone-machine":  `{"machine":"gone-machine","healthy":true}`,
+			},
+			wantReason:     etcdbootstrapv1.EtcdMemberUnreachableReason,
+			wantMemberKeys: []string{"member.machine", "member.other-machine"},
+			wantSummary:    etcdHealthSummary{Members: 2, HealthyMembers: 1, Leader: "other-machine"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := newCluster("external-etcd-cluster")
+			machine := newMachine(cluster, "machine")
+			machine.Status.Addresses = tt.addresses
+			config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+			config.Status.Initialization.DataSecretCreated = ptr.To(true)
+			other := newMachine(cluster, "other-machine")
+			other.Spec.Bootstrap.ConfigRef.Name = "other-etcdadmConfig"
+
+			objects := []client.Object{cluster, machine, other, config}
+			if tt.existingData != nil {
+				objects = append(objects, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: etcdHealthConfigMapName(cluster)},
+					Data:       tt.existingData,
+				})
+			}
+			myclient := fake.NewClientBuilder().
+				WithScheme(setupScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+				Build()
+			k := &EtcdadmConfigReconciler{
+				Log:                 log.Log,
+				Client:              myclient,
+				EtcdadmInitLock:     &etcdInitLocker{},
+				HealthCheckInterval: time.Minute,
+			}
+
+			result, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+			c := conditions.Get(config, etcdbootstrapv1.EtcdMemberHealthyCondition)
+			g.Expect(c).NotTo(BeNil())
+			g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(c.Reason).To(Equal(tt.wantReason))
+			v1beta1Condition := v1beta1conditions.Get(config, etcdbootstrapv1.EtcdMemberHealthyV1Beta1Condition)
+			g.Expect(v1beta1Condition).NotTo(BeNil())
+			g.Expect(v1beta1Condition.Status).To(Equal(corev1.ConditionFalse))
+			g.Expect(v1beta1Condition.Reason).To(Equal(tt.wantReason))
+
+			configMap := &corev1.ConfigMap{}
+			g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: etcdHealthConfigMapName(cluster)}, configMap)).To(Succeed())
+			keys := make([]string, 0, len(configMap.Data))
+			for key := range configMap.Data {
+				keys = append(keys, key)
+			}
+			g.Expect(keys).To(ConsistOf(append(tt.wantMemberKeys, etcdHealthSummaryKey)))
+			summary := etcdHealthSummary{}
+			g.Expect(json.Unmarshal([]byte(configMap.Data[etcdHealthSummaryKey]), &summary)).To(Succeed())
+			g.Expect(summary).To(Equal(tt.wantSummary))
+		})
+	}
+}
+
+func TestEtcdadmConfigReconciler_ReconcileHealthDisabled(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Status.Initialization.DataSecretCreated = ptr.To(true)
+
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, machine, config).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+
+	result, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	err = myclient.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: etcdHealthConfigMapName(cluster)}, &corev1.ConfigMap{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestEtcdadmConfigReconciler_ReconcileHealthKeepsUnchangedConfigMap(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Status.Initialization.DataSecretCreated = ptr.To(true)
+
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, machine, config).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:                 log.Log,
+		Client:              myclient,
+		EtcdadmInitLock:     &etcdInitLocker{},
+		HealthCheckInterval: time.Minute,
+	}
+
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: etcdHealthConfigMapName(cluster)}
+	g.Expect(k.updateEtcdHealthConfigMap(ctx, cluster, etcdMemberHealth{Machine: "machine", LastCheck: metav1.NewTime(time.Unix(0, 0))})).To(Succeed())
+	configMap := &corev1.ConfigMap{}
+	g.Expect(myclient.Get(ctx, key, configMap)).To(Succeed())
+	resourceVersion := configMap.ResourceVersion
+
+	g.Expect(k.updateEtcdHealthConfigMap(ctx, cluster, etcdMemberHealth{Machine: "machine", LastCheck: metav1.NewTime(time.Unix(60, 0))})).To(Succeed())
+	g.Expect(myclient.Get(ctx, key, configMap)).To(Succeed())
+	g.Expect(configMap.ResourceVersion).To(Equal(resourceVersion))
+
+	g.Expect(k.updateEtcdHealthConfigMap(ctx, cluster, etcdMemberHealth{Machine: "machine", Healthy: true, LastCheck: metav1.NewTime(time.Unix(120, 0))})).To(Succeed())
+	g.Expect(myclient.Get(ctx, key, configMap)).To(Succeed())
+	g.Expect(configMap.ResourceVersion).NotTo(Equal(resourceVersion))
+	health := etcdMemberHealth{}
+	g.Expect(json.Unmarshal([]byte(configMap.Data["member.machine"]), &health)).To(Succeed())
+	g.Expect(health.Healthy).To(BeTrue())
+	g.Expect(health.LastCheck.Unix()).To(Equal(int64(120)))
+}
+
+func TestEtcdadmConfigReconciler_ReconcileHealthRetriesOnConflict(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: etcdHealthConfigMapName(cluster)},
+	}
+
+	conflicts := 0
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, machine, config, configMap).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*corev1.ConfigMap); ok && conflicts == 0 {
+					conflicts++
+					return apierrors.NewConflict(corev1.Resource("configmaps"), obj.GetName(), errors.New("modified"))
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:    log.Log,
+		Client: myclient,
+	}
+
+	g.Expect(k.updateEtcdHealthConfigMap(ctx, cluster, etcdMemberHealth{Machine: "machine", Healthy: true})).To(Succeed())
+	g.Expect(conflicts).To(Equal(1))
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+	g.Expect(configMap.Data).To(HaveKey("member.machine"))
+}
+
+func TestEtcdClientTLSConfigCache(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	newTLSConfig := func(notAfter time.Time) *tls.Config {
+		return &tls.Config{Certificates: []tls.Certificate{{Leaf: &x509.Certificate{NotAfter: notAfter}}}}
+	}
+	cluster := client.ObjectKey{Namespace: "default", Name: "cluster"}
+	other := client.ObjectKey{Namespace: "default", Name: "other"}
+
+	cache := etcdClientTLSConfigCache{}
+	g.Expect(cache.get(cluster, now)).To(BeNil())
+
+	tlsConfig := newTLSConfig(now.Add(time.Hour))
+	cache.set(cluster, tlsConfig, now)
+	g.Expect(cache.get(cluster, now)).To(BeIdenticalTo(tlsConfig))
+	g.Expect(cache.get(other, now)).To(BeNil())
+	g.Expect(cache.get(cluster, now.Add(50*time.Minute))).To(BeNil(), "the client certificate is about to expire")
+
+	cache.forget(cluster)
+	g.Expect(cache.get(cluster, now)).To(BeNil())
+
+	cache.set(cluster, newTLSConfig(now.Add(time.Minute)), now)
+	cache.set(other, tlsConfig, now.Add(2*time.Minute))
+	g.Expect(cache.configs).To(HaveLen(1), "expired client certificates are dropped")
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/internal/etcd"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/secret"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const etcdClientPort = "2379"
+
+// etcdMemberRemovalTimeout bounds the removal of an etcd member, as the etcd client waits for unreachable endpoints
+// until its context is done. It is a variable so that tests can shorten it.
+var etcdMemberRemovalTimeout = 30 * time.Second
+
+var errEtcdCANotFound = errors.New("etcd CA not found")
+
+// reconcileDelete removes the etcd member of the Machine owning the EtcdadmConfig, so that the member list
+// does not keep a stale member once the Machine is gone.
+func (r *EtcdadmConfigReconciler) reconcileDelete(ctx context.Context, config *etcdbootstrapv1.EtcdadmConfig, log logr.Logger) (_ ctrl.Result, rerr error) {
+	if !controllerutil.ContainsFinalizer(config, etcdbootstrapv1.EtcdMemberFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(config, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, config); err != nil {
+			log.Error(err, "Failed to patch etcdadmConfig")
+			if rerr == nil {
+				rerr = err
+			}
+		}
+	}()
+
+	machine, err := util.GetOwnerMachine(ctx, r.Client, config.ObjectMeta)
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "could not get owner machine for the EtcdadmConfig")
+		return ctrl.Result{}, err
+	}
+	if machine == nil {
+		log.Info("Owner Machine is gone, skipping etcd member removal")
+		controllerutil.RemoveFinalizer(config, etcdbootstrapv1.EtcdMemberFinalizer)
+		return ctrl.Result{}, nil
+	}
+	log = log.WithValues("machine-name", machine.Name)
+
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machine.ObjectMeta)
+	if err != nil {
+		if errors.Cause(err) == util.ErrNoCluster || apierrors.IsNotFound(err) {
+			log.Info("Cluster is gone, skipping etcd member removal")
+			controllerutil.RemoveFinalizer(config, etcdbootstrapv1.EtcdMemberFinalizer)
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "could not get cluster by machine metadata")
+		return ctrl.Result{}, err
+	}
+
+	if annotations.IsPaused(cluster, config) {
+		log.Info("Reconciliation is paused for this object")
+		return ctrl.Result{}, nil
+	}
+
+	// the whole etcd cluster goes away with the cluster, there are no members left to keep consistent
+	if !cluster.DeletionTimestamp.IsZero() {
+		controllerutil.RemoveFinalizer(config, etcdbootstrapv1.EtcdMemberFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.removeEtcdMember(ctx, cluster, machine, log); err != nil {
+		log.Error(err, "Failed to remove etcd member")
+		return ctrl.Result{}, err
+	}
+	controllerutil.RemoveFinalizer(config, etcdbootstrapv1.EtcdMemberFinalizer)
+	return ctrl.Result{}, nil
+}
+
+func (r *EtcdadmConfigReconciler) removeEtcdMember(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine, log logr.Logger) error {
+	endpoints, err := r.etcdEndpoints(ctx, cluster, machine)
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		log.Info("No other etcd machines left, skipping etcd member removal")
+		return nil
+	}
+
+	tlsConfig, err := r.etcdClientTLSConfig(ctx, cluster)
+	if errors.Is(err, errEtcdCANotFound) {
+		log.Info("Etcd CA is gone, skipping etcd member removal")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	removeCtx, cancel := context.WithTimeout(ctx, etcdMemberRemovalTimeout)
+	defer cancel()
+	removed, err := etcd.RemoveMember(removeCtx, endpoints, tlsConfig, machine.Name, machineHosts(machine))
+	if err != nil {
+		return err
+	}
+	for _, id := range removed {
+		log.Info("Removed etcd member", "member-id", fmt.Sprintf("%x", id))
+	}
+	return nil
+}
+
+// etcdClientTLSConfig returns a TLS config to connect to the etcd cluster with a client certificate
+// signed by its CA.
+func (r *EtcdadmConfigReconciler) etcdClientTLSConfig(ctx context.Context, cluster *clusterv1.Cluster) (*tls.Config, error) {
+	etcdCerts := render.EtcdCACertificates()
+	if err := etcdCerts.Lookup(ctx, r.Client, util.ObjectKey(cluster)); err != nil {
+		return nil, errors.Wrap(err, "failed doing a lookup for the etcd CA")
+	}
+	ca := etcdCerts.GetByPurpose(secret.ManagedExternalEtcdCA)
+	if ca == nil || ca.KeyPair == nil {
+		return nil, errEtcdCANotFound
+	}
+	return etcd.ClientTLSConfig(ca.KeyPair.Cert, ca.KeyPair.Key)
+}
+
+// etcdEndpoints returns the client URLs of the etcd machines of the cluster that stay around.
+func (r *EtcdadmConfigReconciler) etcdEndpoints(ctx context.Context, cluster *clusterv1.Cluster, departing *clusterv1.Machine) ([]string, error) {
+	machines, err := r.etcdMachines(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []string
+	for _, m := range machines {
+		if m.Name == departing.Name || !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, endpoint := range machineEndpoints(&m) {
+			if !slices.Contains(endpoints, endpoint) {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// etcdMachines returns the Machines of the cluster bootstrapped with an EtcdadmConfig.
+func (r *EtcdadmConfigReconciler) etcdMachines(ctx context.Context, cluster *clusterv1.Cluster) ([]clusterv1.Machine, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machineList, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Machines of Cluster %s/%s", cluster.Namespace, cluster.Name)
+	}
+
+	var machines []clusterv1.Machine
+	for _, m := range machineList.Items {
+		if m.Spec.Bootstrap.ConfigRef.IsDefined() && m.Spec.Bootstrap.ConfigRef.Kind == "EtcdadmConfig" {
+			machines = append(machines, m)
+		}
+	}
+	return machines, nil
+}
+
+// machineEndpoints returns the etcd client URLs on the IP addresses of machine.
+func machineEndpoints(machine *clusterv1.Machine) []string {
+	var endpoints []string
+	for _, address := range machine.Status.Addresses {
+		if address.Type != clusterv1.MachineInternalIP && address.Type != clusterv1.MachineExternalIP {
+			continue
+		}
+		endpoints = append(endpoints, fmt.Sprintf("https://%s", net.JoinHostPort(address.Address, etcdClientPort)))
+	}
+	return endpoints
+}
+
+// machineHosts returns the addresses the etcd member of machine may advertise its peer URL on.
+func machineHosts(machine *clusterv1.Machine) []string {
+	hosts := make([]string, 0, len(machine.Status.Addresses))
+	for _, address := range machine.Status.Addresses {
+		hosts = append(hosts, address.Address)
+	}
+	return hosts
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestEtcdadmConfigReconciler_AddsEtcdMemberFinalizer(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Status.Initialization.DataSecretCreated = ptr.To(true)
+
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, machine, config).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+
+	_, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+	g.Expect(config.Finalizers).To(ContainElement(etcdbootstrapv1.EtcdMemberFinalizer))
+}
+
+func TestEtcdadmConfigReconciler_ReconcileDelete(t *testing.T) {
+	tests := []struct {
+		name             string
+		clusterDeleting  bool
+		otherMachine     bool
+		ownerMachineGone bool
+	}{
+		{
+			name: "last etcd machine",
+		},
+		{
+			name:            "cluster is being deleted",
+			clusterDeleting: true,
+			otherMachine:    true,
+		},
+		{
+			name:         "etcd CA is gone",
+			otherMachine: true,
+		},
+		{
+			name:             "owner machine is gone",
+			otherMachine:     true,
+			ownerMachineGone: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := newCluster("external-etcd-cluster")
+			if tt.clusterDeleting {
+				cluster.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+				cluster.Finalizers = []string{clusterv1.ClusterFinalizer}
+			}
+			machine := newMachine(cluster, "machine")
+			machine.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}}
+			config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+			config.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+			config.Finalizers = []string{etcdbootstrapv1.EtcdMemberFinalizer}
+
+			objects := []client.Object{cluster, config}
+			if !tt.ownerMachineGone {
+				objects = append(objects, machine)
+			}
+			if tt.otherMachine {
+				other := newMachine(cluster, "other-machine")
+				other.Spec.Bootstrap.ConfigRef.Name = "other-etcdadmConfig"
+				other.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.2"}}
+				objects = append(objects, other)
+			}
+			myclient := fake.NewClientBuilder().
+				WithScheme(setupScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+				Build()
+			k := &EtcdadmConfigReconciler{
+				Log:             log.Log,
+				Client:          myclient,
+				EtcdadmInitLock: &etcdInitLocker{},
+			}
+
+			_, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+			g.Expect(err).NotTo(HaveOccurred())
+			// the config is gone once its last finalizer is removed
+			err = myclient.Get(ctx, client.ObjectKeyFromObject(config), &etcdbootstrapv1.EtcdadmConfig{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	}
+}
+
+// Removing the member gives up once etcd cannot be reached for etcdMemberRemovalTimeout, rather than blocking the worker
+func TestEtcdadmConfigReconciler_ReconcileDeleteEtcdUnreachable(t *testing.T) {
+	g := NewWithT(t)
+
+	timeout := etcdMemberRemovalTimeout
+	etcdMemberRemovalTimeout = time.Second
+	defer func() { etcdMemberRemovalTimeout = timeout }()
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	machine.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}}
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+	config.Finalizers = []string{etcdbootstrapv1.EtcdMemberFinalizer}
+	// nothing listens on the etcd client port of the other machine
+	other := newMachine(cluster, "other-machine")
+	other.Spec.Bootstrap.ConfigRef.Name = "other-etcdadmConfig"
+	other.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "127.0.0.1"}}
+
+	etcdCACerts := render.EtcdCACertificates()
+	g.Expect(etcdCACerts.Generate()).To(Succeed())
+	etcdCASecret := etcdCACerts[0].AsSecret(client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
+
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, machine, other, config, etcdCASecret).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+
+	start := time.Now()
+	_, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+
+	// the finalizer is kept, so the member is removed once etcd can be reached again
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+	g.Expect(config.Finalizers).To(ContainElement(etcdbootstrapv1.EtcdMemberFinalizer))
+}
+
+func TestEtcdadmConfigReconciler_EtcdEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	departing := newMachine(cluster, "departing")
+	departing.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}}
+
+	surviving := newMachine(cluster, "surviving")
+	surviving.Status.Addresses = clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.2"},
+		{Type: clusterv1.MachineExternalIP, Address: "fd00::2"},
+		{Type: clusterv1.MachineHostName, Address: "surviving"},
+	}
+
+	deleting := newMachine(cluster, "deleting")
+	deleting.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+	deleting.Finalizers = []string{clusterv1.MachineFinalizer}
+	deleting.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.3"}}
+
+	controlPlane := newMachine(cluster, "control-plane")
+	controlPlane.Spec.Bootstrap.ConfigRef.Kind = "KubeadmConfig"
+	controlPlane.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.4"}}
+
+	otherCluster := newMachine(newCluster("other-cluster"), "other-cluster-machine")
+	otherCluster.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.5"}}
+
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, departing, surviving, deleting, controlPlane, otherCluster).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:    log.Log,
+		Client: myclient,
+	}
+
+	endpoints, err := k.etcdEndpoints(ctx, cluster, departing)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(endpoints).To(ConsistOf("https://10.0.0.2:2379", "https://[fd00::2]:2379"))
+}
@@ -19,16 +19,14 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 
-	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/aws/etcdadm-bootstrap-provider/internal/locking"
 	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
-	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/bottlerocket"
-	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/cloudinit"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -36,25 +34,27 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
-	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
-	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
-	v1beta1patch "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/patch"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	"sigs.k8s.io/cluster-api/util/secret"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 )
 
-const stopKubeletCommand = "systemctl stop kubelet"
 const registrySecretName = "registry-credentials"
 const registryUsernameKey = "username"
 const registryPasswordKey = "password"
+const backupAccessKeyIDKey = "accessKeyID"
+const backupSecretAccessKeyKey = "secretAccessKey"
+const defaultSnapshotKey = "snapshot.db"
 
 // InitLocker is a lock that is used around etcdadm init
 type InitLocker interface {
@@ -62,9 +62,6 @@ type InitLocker interface {
 	Unlock(ctx context.Context, cluster *clusterv1.Cluster) bool
 }
 
-// TODO: replace with etcdadm release
-var defaultEtcdadmInstallCommands = []string{`curl -OL https://github.com/mrajashree/etcdadm-bootstrap-provider/releases/download/v0.0.0/etcdadm`, `chmod +x etcdadm`, `mv etcdadm /usr/local/bin/etcdadm`}
-
 // EtcdadmConfigReconciler reconciles a EtcdadmConfig object
 type EtcdadmConfigReconciler struct {
 	client.Client
@@ -72,6 +69,15 @@ type EtcdadmConfigReconciler struct {
 	Scheme *runtime.Scheme
 
 	EtcdadmInitLock InitLocker
+	// HealthCheckInterval is the interval between two health checks of the etcd member of a ready
+	// EtcdadmConfig. Health checks are disabled when it is zero.
+	HealthCheckInterval time.Duration
+	// MaxSnapshotBootstrapDataSize is the maximum size of bootstrap data embedding a restore snapshot read from a
+	// Secret or ConfigMap, which has to fit in the user data of the machine. The size is not checked when it is zero.
+	MaxSnapshotBootstrapDataSize int
+
+	// healthTLSConfigs caches the client certificates of the health checks per cluster.
+	healthTLSConfigs etcdClientTLSConfigCache
 }
 
 type Scope struct {
@@ -97,6 +103,10 @@ func (r *EtcdadmConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(r.ClusterToEtcdadmConfigs),
 			builder.WithPredicates(predicates.ClusterUnpausedAndInfrastructureProvisioned(r.Scheme, r.Log)),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.SecretToEtcdadmConfigs),
 		).Complete(r)
 
 	if err != nil {
@@ -124,6 +134,10 @@ func (r *EtcdadmConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	if !etcdadmConfig.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, etcdadmConfig, log)
+	}
+
 	// Look up the Machine associated with this EtcdadmConfig resource
 	machine, err := util.GetOwnerMachine(ctx, r.Client, etcdadmConfig.ObjectMeta)
 	if err != nil {
@@ -159,7 +173,7 @@ func (r *EtcdadmConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 	// Initialize the patch helper.
-	patchHelper, err := v1beta1patch.NewHelper(etcdadmConfig, r.Client)
+	patchHelper, err := patch.NewHelper(etcdadmConfig, r.Client)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -169,13 +183,38 @@ func (r *EtcdadmConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		// always update the readyCondition; the summary is represented using the "1 of x completed" notation.
 		v1beta1conditions.SetSummary(etcdadmConfig,
 			v1beta1conditions.WithConditions(
-				etcdbootstrapv1.DataSecretAvailableCondition,
+				etcdbootstrapv1.DataSecretAvailableV1Beta1Condition,
 			),
 		)
+		if err := conditions.SetSummaryCondition(etcdadmConfig, etcdadmConfig, clusterv1.ReadyCondition,
+			conditions.ForConditionTypes{
+				etcdbootstrapv1.DataSecretAvailableCondition,
+			},
+		); err != nil {
+			log.Error(err, "Failed to set Ready condition")
+			if rerr == nil {
+				rerr = err
+			}
+		}
 		// Patch ObservedGeneration only if the reconciliation completed successfully
-		patchOpts := []v1beta1patch.Option{}
+		patchOpts := []patch.Option{
+			patch.WithOwnedConditions{Conditions: []string{
+				clusterv1.ReadyCondition,
+				etcdbootstrapv1.DataSecretAvailableCondition,
+				etcdbootstrapv1.EtcdMemberHealthyCondition,
+				etcdbootstrapv1.APIServerEtcdClientCertificateAvailableCondition,
+				etcdbootstrapv1.RegistryCredentialsAvailableCondition,
+			}},
+			patch.WithOwnedV1Beta1Conditions{Conditions: []clusterv1.ConditionType{
+				clusterv1.ReadyV1Beta1Condition,
+				etcdbootstrapv1.DataSecretAvailableV1Beta1Condition,
+				etcdbootstrapv1.EtcdMemberHealthyV1Beta1Condition,
+				etcdbootstrapv1.APIServerEtcdClientCertificateAvailableV1Beta1Condition,
+				etcdbootstrapv1.RegistryCredentialsAvailableV1Beta1Condition,
+			}},
+		}
 		if rerr == nil {
-			patchOpts = append(patchOpts, v1beta1patch.WithStatusObservedGeneration{})
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
 		}
 		if err := patchHelper.Patch(ctx, etcdadmConfig, patchOpts...); err != nil {
 			log.Error(err, "Failed to patch etcdadmConfig")
@@ -185,9 +224,8 @@ func (r *EtcdadmConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}()
 
-	if etcdadmConfig.Status.Ready {
-		return ctrl.Result{}, nil
-	}
+	// configs that are already ready get the finalizer too, so the members of existing machines are removed as well
+	controllerutil.AddFinalizer(etcdadmConfig, etcdbootstrapv1.EtcdMemberFinalizer)
 
 	scope := Scope{
 		Logger:  log,
@@ -196,13 +234,39 @@ func (r *EtcdadmConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		Machine: machine,
 	}
 
-	if !conditions.IsTrue(cluster, string(clusterv1.ManagedExternalEtcdClusterInitializedCondition)) {
-		return r.initializeEtcd(ctx, &scope)
+	// the preview is rendered next to the bootstrap data, so that annotating a config never holds back its Machine, and
+	// its failures are only reported on the preview
+	if _, ok := etcdadmConfig.Annotations[etcdbootstrapv1.PreviewAnnotation]; ok {
+		if err := r.reconcilePreview(ctx, &scope); err != nil {
+			log.Error(err, "Failed to store bootstrap data preview")
+		}
+	}
+
+	return r.reconcileBootstrapData(ctx, &scope)
+}
+
+func (r *EtcdadmConfigReconciler) reconcileBootstrapData(ctx context.Context, scope *Scope) (ctrl.Result, error) {
+	log := scope.Logger
+	if ptr.Deref(scope.Config.Status.Initialization.DataSecretCreated, false) {
+		renewIn, err := r.renewAPIServerEtcdClientCertificate(ctx, scope)
+		if err != nil {
+			log.Error(err, "Failed to renew kube-apiserver etcd client certificate")
+			return ctrl.Result{}, err
+		}
+		res, err := r.reconcileHealth(ctx, scope)
+		if err == nil && renewIn > 0 && (res.RequeueAfter == 0 || renewIn < res.RequeueAfter) {
+			res.RequeueAfter = renewIn
+		}
+		return res, err
+	}
+
+	if !conditions.IsTrue(scope.Cluster, string(clusterv1.ManagedExternalEtcdClusterInitializedCondition)) {
+		return r.initializeEtcd(ctx, scope)
 	}
 	// Unlock any locks that might have been set during init process
-	r.EtcdadmInitLock.Unlock(ctx, cluster)
+	r.EtcdadmInitLock.Unlock(ctx, scope.Cluster)
 
-	res, err := r.joinEtcd(ctx, &scope)
+	res, err := r.joinEtcd(ctx, scope)
 	if err != nil {
 		return res, err
 	}
@@ -226,58 +290,38 @@ func (r *EtcdadmConfigReconciler) initializeEtcd(ctx context.Context, scope *Sco
 	}()
 	log.Info("Creating cloudinit for the init etcd plane")
 
-	CACertKeyPair := etcdCACertKeyPair()
+	CACertKeyPair := render.EtcdCACertificates()
 	rerr = CACertKeyPair.LookupOrGenerate(
 		ctx,
 		r.Client,
 		util.ObjectKey(scope.Cluster),
 		*metav1.NewControllerRef(scope.Config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")),
 	)
-
-	initInput := userdata.EtcdPlaneInput{
-		BaseUserData: userdata.BaseUserData{
-			Users:              scope.Config.Spec.Users,
-			PreEtcdadmCommands: scope.Config.Spec.PreEtcdadmCommands,
-			NTP:                scope.Config.Spec.NTP,
-			Hostname:           scope.Machine.Name,
-		},
-		Certificates: CACertKeyPair,
-	}
-
-	// grab user pass for registry mirror
-	if scope.Config.Spec.RegistryMirror != nil {
-		username, password, err := r.resolveRegistryCredentials(ctx, scope.Config)
-		if err != nil {
-			log.Info("Cannot find secret for registry credentials, proceeding without registry credentials")
-		} else {
-			initInput.RegistryMirrorCredentials.Username = string(username)
-			initInput.RegistryMirrorCredentials.Password = string(password)
+	if rerr == nil {
+		if _, err := r.reconcileAPIServerEtcdClientCertificate(ctx, scope, CACertKeyPair); err != nil {
+			log.Error(err, "Failed to issue kube-apiserver etcd client certificate")
+			return ctrl.Result{}, err
 		}
 	}
 
-	// only do this if etcdadm not baked in image
-	if !scope.Config.Spec.EtcdadmBuiltin {
-		if len(scope.Config.Spec.EtcdadmInstallCommands) > 0 {
-			initInput.PreEtcdadmCommands = append(initInput.PreEtcdadmCommands, scope.Config.Spec.EtcdadmInstallCommands...)
-		} else {
-			initInput.PreEtcdadmCommands = append(initInput.PreEtcdadmCommands, defaultEtcdadmInstallCommands...)
-		}
+	initInput, err := r.newInitInput(ctx, scope, CACertKeyPair)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	var bootstrapData []byte
-	var err error
-
-	switch scope.Config.Spec.Format {
-	case etcdbootstrapv1.Bottlerocket:
-		bootstrapData, err = bottlerocket.NewInitEtcdPlane(&initInput, scope.Config.Spec, log)
-	default:
-		initInput.PreEtcdadmCommands = append(initInput.PreEtcdadmCommands, stopKubeletCommand)
-		bootstrapData, err = cloudinit.NewInitEtcdPlane(&initInput, scope.Config.Spec)
+	spec, err := r.renderSpec(ctx, scope, "")
+	if err != nil {
+		return ctrl.Result{}, err
 	}
+	bootstrapData, err := render.Init(initInput, spec, log)
 	if err != nil {
 		log.Error(err, "Failed to generate cloud init for initializing etcd plane")
 		return ctrl.Result{}, err
 	}
+	if err := r.checkSnapshotBootstrapDataSize(scope.Config, bootstrapData); err != nil {
+		log.Error(err, "Bootstrap data embedding the etcd snapshot to restore from is too large")
+		return ctrl.Result{}, err
+	}
 
 	if err := r.storeBootstrapData(ctx, scope.Config, bootstrapData, scope.Cluster.Name); err != nil {
 		log.Error(err, "Failed to store bootstrap data")
@@ -288,20 +332,12 @@ func (r *EtcdadmConfigReconciler) initializeEtcd(ctx context.Context, scope *Sco
 
 func (r *EtcdadmConfigReconciler) joinEtcd(ctx context.Context, scope *Scope) (_ ctrl.Result, rerr error) {
 	log := r.Log
-	etcdSecretName := fmt.Sprintf("%v-%v", scope.Cluster.Name, "etcd-init")
-	existingSecret := &corev1.Secret{}
-	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: scope.Cluster.Namespace, Name: etcdSecretName}, existingSecret); err != nil {
-		if apierrors.IsNotFound(err) {
-			// this is not an error, just means the first machine didn't get an address yet, reconcile
-			log.Info("Waiting for Machine Controller to set address on init machine and returning error")
-			return ctrl.Result{}, err
-		}
-		log.Error(err, "Failed to get secret containing first machine address")
+	joinAddress, err := r.resolveJoinAddress(ctx, scope)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
-	log.Info("Machine Controller has set address on init machine")
 
-	etcdCerts := etcdCACertKeyPair()
+	etcdCerts := render.EtcdCACertificates()
 	if err := etcdCerts.Lookup(
 		ctx,
 		r.Client,
@@ -309,55 +345,21 @@ func (r *EtcdadmConfigReconciler) joinEtcd(ctx context.Context, scope *Scope) (_
 	); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed doing a lookup for certs during join")
 	}
-
-	var joinAddress string
-	if clientURL, ok := existingSecret.Data["clientUrls"]; ok {
-		joinAddress = string(clientURL)
-	} else {
-		initMachineAddress := string(existingSecret.Data["address"])
-		joinAddress = fmt.Sprintf("https://%v:2379", initMachineAddress)
-	}
-
-	joinInput := userdata.EtcdPlaneJoinInput{
-		BaseUserData: userdata.BaseUserData{
-			Users:              scope.Config.Spec.Users,
-			PreEtcdadmCommands: scope.Config.Spec.PreEtcdadmCommands,
-			NTP:                scope.Config.Spec.NTP,
-			Hostname:           scope.Machine.Name,
-		},
-		JoinAddress:  joinAddress,
-		Certificates: etcdCerts,
-	}
-
-	// grab user pass for registry mirror
-	if scope.Config.Spec.RegistryMirror != nil {
-		username, password, err := r.resolveRegistryCredentials(ctx, scope.Config)
-		if err != nil {
-			log.Info("Cannot find secret for registry credentials, proceeding without registry credentials")
-		} else {
-			joinInput.RegistryMirrorCredentials.Username = string(username)
-			joinInput.RegistryMirrorCredentials.Password = string(password)
-		}
+	if _, err := r.reconcileAPIServerEtcdClientCertificate(ctx, scope, etcdCerts); err != nil {
+		log.Error(err, "Failed to issue kube-apiserver etcd client certificate")
+		return ctrl.Result{}, err
 	}
 
-	if !scope.Config.Spec.EtcdadmBuiltin {
-		if len(scope.Config.Spec.EtcdadmInstallCommands) > 0 {
-			joinInput.PreEtcdadmCommands = append(joinInput.PreEtcdadmCommands, scope.Config.Spec.EtcdadmInstallCommands...)
-		} else {
-			joinInput.PreEtcdadmCommands = append(joinInput.PreEtcdadmCommands, defaultEtcdadmInstallCommands...)
-		}
+	joinInput, err := r.newJoinInput(ctx, scope, joinAddress, etcdCerts)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	var bootstrapData []byte
-	var err error
-
-	switch scope.Config.Spec.Format {
-	case etcdbootstrapv1.Bottlerocket:
-		bootstrapData, err = bottlerocket.NewJoinEtcdPlane(&joinInput, scope.Config.Spec, log)
-	default:
-		joinInput.PreEtcdadmCommands = append(joinInput.PreEtcdadmCommands, stopKubeletCommand)
-		bootstrapData, err = cloudinit.NewJoinEtcdPlane(&joinInput, scope.Config.Spec)
+	spec, err := r.renderSpec(ctx, scope, joinAddress)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
+	bootstrapData, err := render.Join(joinInput, spec, log)
 	if err != nil {
 		log.Error(err, "Failed to generate cloud init for bootstrap etcd plane - join")
 		return ctrl.Result{}, err
@@ -370,17 +372,98 @@ func (r *EtcdadmConfigReconciler) joinEtcd(ctx context.Context, scope *Scope) (_
 	return ctrl.Result{}, nil
 }
 
-func etcdCACertKeyPair() secret.Certificates {
-	certificatesDir := "/etc/etcd/pki"
-	certificates := secret.Certificates{
-		&secret.Certificate{
-			Purpose:  secret.ManagedExternalEtcdCA,
-			CertFile: filepath.Join(certificatesDir, "ca.crt"),
-			KeyFile:  filepath.Join(certificatesDir, "ca.key"),
-		},
+// newInitInput returns the input to render the bootstrap data of the machine initializing the etcd cluster with,
+// along with the credentials and snapshot it references in the management cluster.
+func (r *EtcdadmConfigReconciler) newInitInput(ctx context.Context, scope *Scope, etcdCerts secret.Certificates) (*userdata.EtcdPlaneInput, error) {
+	log := r.Log
+	initInput := &userdata.EtcdPlaneInput{
+		BaseUserData: render.BaseUserData(scope.Config.Spec, scope.Machine.Name),
+		Certificates: etcdCerts,
+	}
+	if err := r.resolveCredentials(ctx, scope.Config, &initInput.BaseUserData); err != nil {
+		return nil, err
+	}
+
+	// grab the snapshot to restore the cluster from if it is stored in the management cluster
+	if restore := scope.Config.Spec.RestoreFrom; restore != nil && (restore.SecretRef != nil || restore.ConfigMapRef != nil) {
+		snapshot, err := r.resolveRestoreSnapshot(ctx, scope.Config)
+		if err != nil {
+			log.Error(err, "Failed to resolve etcd snapshot to restore from")
+			return nil, err
+		}
+		initInput.RestoreSnapshot = snapshot
 	}
+	return initInput, nil
+}
 
-	return certificates
+// newJoinInput returns the input to render the bootstrap data of a machine joining the etcd member at joinAddress with,
+// along with the credentials it references in the management cluster.
+func (r *EtcdadmConfigReconciler) newJoinInput(ctx context.Context, scope *Scope, joinAddress string, etcdCerts secret.Certificates) (*userdata.EtcdPlaneJoinInput, error) {
+	joinInput := &userdata.EtcdPlaneJoinInput{
+		BaseUserData: render.BaseUserData(scope.Config.Spec, scope.Machine.Name),
+		JoinAddress:  joinAddress,
+		Certificates: etcdCerts,
+	}
+	if err := r.resolveCredentials(ctx, scope.Config, &joinInput.BaseUserData); err != nil {
+		return nil, err
+	}
+	return joinInput, nil
+}
+
+// resolveJoinAddress returns the client URL of the first etcd member, published by the machine controller once the
+// init machine has an address.
+func (r *EtcdadmConfigReconciler) resolveJoinAddress(ctx context.Context, scope *Scope) (string, error) {
+	log := r.Log
+	etcdSecretName := fmt.Sprintf("%v-%v", scope.Cluster.Name, "etcd-init")
+	existingSecret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: scope.Cluster.Namespace, Name: etcdSecretName}, existingSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			// this is not an error, just means the first machine didn't get an address yet, reconcile
+			log.Info("Waiting for Machine Controller to set address on init machine and returning error")
+			return "", err
+		}
+		log.Error(err, "Failed to get secret containing first machine address")
+		return "", err
+	}
+	log.Info("Machine Controller has set address on init machine")
+
+	if clientURL, ok := existingSecret.Data["clientUrls"]; ok {
+		return string(clientURL), nil
+	}
+	initMachineAddress := string(existingSecret.Data["address"])
+	return fmt.Sprintf("https://%v:2379", initMachineAddress), nil
+}
+
+// resolveCredentials sets the registry mirror and etcd backup credentials of input from the Secrets config references.
+func (r *EtcdadmConfigReconciler) resolveCredentials(ctx context.Context, config *etcdbootstrapv1.EtcdadmConfig, input *userdata.BaseUserData) error {
+	log := r.Log
+	// grab the registry mirror credentials, which are only optional when the config does not reference them
+	if registryMirror := config.Spec.RegistryMirror; registryMirror != nil {
+		credentials, err := r.resolveRegistryCredentials(ctx, config)
+		switch {
+		case err == nil:
+			input.RegistryMirrorCredentials = credentials
+			markRegistryCredentialsAvailable(config)
+		case registryMirror.CredentialsRef != nil:
+			log.Error(err, "Failed to resolve registry credentials")
+			markRegistryCredentialsUnavailable(config, err)
+			return err
+		default:
+			log.Info("Cannot find secret for registry credentials, proceeding without registry credentials")
+			markRegistryCredentialsNotFound(config, err)
+		}
+	}
+
+	// grab the object store credentials for etcd backups
+	if config.Spec.Backup != nil && config.Spec.Backup.S3 != nil {
+		credentials, err := r.resolveBackupCredentials(ctx, config)
+		if err != nil {
+			log.Error(err, "Failed to resolve etcd backup credentials")
+			return err
+		}
+		input.BackupCredentials = credentials
+	}
+	return nil
 }
 
 // storeBootstrapData creates a new secret with the data passed in as input,
@@ -422,28 +505,91 @@ func (r *EtcdadmConfigReconciler) storeBootstrapData(ctx context.Context, config
 			return errors.Wrapf(err, "failed to update bootstrap data secret for EtcdadmConfig %s/%s", config.Namespace, config.Name)
 		}
 	}
-	config.Status.DataSecretName = ptr.To(se.Name)
-	config.Status.Ready = true
-	v1beta1conditions.MarkTrue(config, bootstrapv1.DataSecretAvailableCondition)
+	config.Status.DataSecretName = se.Name
+	config.Status.Initialization.DataSecretCreated = ptr.To(true)
+	conditions.Set(config, metav1.Condition{
+		Type:   etcdbootstrapv1.DataSecretAvailableCondition,
+		Status: metav1.ConditionTrue,
+		Reason: etcdbootstrapv1.DataSecretAvailableReason,
+	})
+	v1beta1conditions.MarkTrue(config, etcdbootstrapv1.DataSecretAvailableV1Beta1Condition)
 	return nil
 }
 
-func (r *EtcdadmConfigReconciler) resolveRegistryCredentials(ctx context.Context, config *etcdbootstrapv1.EtcdadmConfig) ([]byte, []byte, error) {
+func (r *EtcdadmConfigReconciler) resolveBackupCredentials(ctx context.Context, config *etcdbootstrapv1.EtcdadmConfig) (userdata.BackupCredentials, error) {
 	secret := &corev1.Secret{}
-	key := types.NamespacedName{Namespace: config.Namespace, Name: registrySecretName}
+	key := types.NamespacedName{Namespace: config.Namespace, Name: config.Spec.Backup.S3.CredentialsSecretRef.Name}
 	if err := r.Client.Get(ctx, key, secret); err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil, nil, errors.Wrapf(err, "secret not found: %s", key)
-		}
-		return nil, nil, errors.Wrapf(err, "failed to retrieve Secret %q", key)
+		return userdata.BackupCredentials{}, errors.Wrapf(err, "failed to retrieve Secret %q", key)
 	}
-	username, ok := secret.Data[registryUsernameKey]
+	accessKeyID, ok := secret.Data[backupAccessKeyIDKey]
 	if !ok {
-		return nil, nil, errors.Errorf("secret references non-existent secret key: %q", "username")
+		return userdata.BackupCredentials{}, errors.Errorf("secret %q is missing key %q", key, backupAccessKeyIDKey)
 	}
-	password, ok := secret.Data[registryPasswordKey]
+	secretAccessKey, ok := secret.Data[backupSecretAccessKeyKey]
 	if !ok {
-		return nil, nil, errors.Errorf("secret references non-existent secret key: %q", "password")
+		return userdata.BackupCredentials{}, errors.Errorf("secret %q is missing key %q", key, backupSecretAccessKeyKey)
+	}
+	return userdata.BackupCredentials{
+		AccessKeyID:     string(accessKeyID),
+		SecretAccessKey: string(secretAccessKey),
+	}, nil
+}
+
+func (r *EtcdadmConfigReconciler) resolveRestoreSnapshot(ctx context.Context, config *etcdbootstrapv1.EtcdadmConfig) ([]byte, error) {
+	restore := config.Spec.RestoreFrom
+	if restore.SecretRef != nil {
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: config.Namespace, Name: restore.SecretRef.Name}
+		if err := r.Client.Get(ctx, key, secret); err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve Secret %q", key)
+		}
+		dataKey := snapshotKey(restore.SecretRef)
+		snapshot, ok := secret.Data[dataKey]
+		if !ok {
+			return nil, errors.Errorf("secret %q is missing key %q", key, dataKey)
+		}
+		return snapshot, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: config.Namespace, Name: restore.ConfigMapRef.Name}
+	if err := r.Client.Get(ctx, key, configMap); err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve ConfigMap %q", key)
+	}
+	dataKey := snapshotKey(restore.ConfigMapRef)
+	snapshot, ok := configMap.BinaryData[dataKey]
+	if !ok {
+		return nil, errors.Errorf("configmap %q is missing binaryData key %q", key, dataKey)
+	}
+	return snapshot, nil
+}
+
+// checkSnapshotBootstrapDataSize makes sure bootstrap data embedding the restore snapshot of config does not exceed
+// MaxSnapshotBootstrapDataSize, since infrastructure providers reject or truncate user data over their limit.
+func (r *EtcdadmConfigReconciler) checkSnapshotBootstrapDataSize(config *etcdbootstrapv1.EtcdadmConfig, bootstrapData []byte) error {
+	restore := config.Spec.RestoreFrom
+	if r.MaxSnapshotBootstrapDataSize <= 0 || restore == nil || (restore.SecretRef == nil && restore.ConfigMapRef == nil) {
+		return nil
+	}
+	if len(bootstrapData) <= r.MaxSnapshotBootstrapDataSize {
+		return nil
+	}
+
+	err := errors.Errorf("bootstrap data embedding the snapshot to restore from is %d bytes, more than the maximum of %d bytes, restore from a url instead", len(bootstrapData), r.MaxSnapshotBootstrapDataSize)
+	conditions.Set(config, metav1.Condition{
+		Type:    etcdbootstrapv1.DataSecretAvailableCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  etcdbootstrapv1.RestoreSnapshotTooLargeReason,
+		Message: err.Error(),
+	})
+	v1beta1conditions.MarkFalse(config, etcdbootstrapv1.DataSecretAvailableV1Beta1Condition, etcdbootstrapv1.RestoreSnapshotTooLargeReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+	return err
+}
+
+func snapshotKey(ref *etcdbootstrapv1.SnapshotSourceReference) string {
+	if ref.Key == "" {
+		return defaultSnapshotKey
 	}
-	return username, password, nil
+	return ref.Key
 }
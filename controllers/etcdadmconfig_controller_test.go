@@ -2,19 +2,23 @@ package controllers
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
-	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util/conditions"
-	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -89,14 +93,14 @@ func TestEtcdadmConfigReconciler_Reconcile_ReturnEarlyIfEtcdadmConfigIsReady(t *
 	cluster := newCluster("external-etcd-cluster")
 	m := newMachine(cluster, "etcd-machine")
 	config := newEtcdadmConfig(m, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
-	config.Status.Ready = true
+	config.Status.Initialization.DataSecretCreated = ptr.To(true)
 
 	objects := []client.Object{
 		cluster,
 		m,
 		config,
 	}
-	myclient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+	myclient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).Build()
 
 	k := &EtcdadmConfigReconciler{
 		Log:    log.Log,
@@ -268,9 +272,9 @@ func TestEtcdadmConfigReconciler_InitializeEtcdIfInitLockIsNotAquired_Cloudinit(
 
 	configKey := client.ObjectKeyFromObject(config)
 	g.Expect(myclient.Get(context.TODO(), configKey, config)).To(Succeed())
-	c := v1beta1conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
 	g.Expect(c).ToNot(BeNil())
-	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
 }
 
 // First Etcdadm Machine must initialize cluster since Cluster.Status.ManagedExternalEtcdInitialized is false and lock is not acquired
@@ -310,9 +314,9 @@ func TestEtcdadmConfigReconciler_InitializeEtcdIfInitLockIsNotAquired_Bottlerock
 
 	configKey := client.ObjectKeyFromObject(config)
 	g.Expect(myclient.Get(context.TODO(), configKey, config)).To(Succeed())
-	c := v1beta1conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
 	g.Expect(c).ToNot(BeNil())
-	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
 }
 
 // The Secret containing bootstrap data for first etcdadm machine exists, but etcdadmConfig status is not patched yet
@@ -376,9 +380,9 @@ func TestEtcdadmConfigBootstrapDataSecretCreatedStatusNotPatched(t *testing.T) {
 
 	configKey := client.ObjectKeyFromObject(config)
 	g.Expect(myclient.Get(context.TODO(), configKey, config)).To(Succeed())
-	c := v1beta1conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
 	g.Expect(c).ToNot(BeNil())
-	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
 }
 
 // NA for EKS-A since it etcdadm is built into the OVA
@@ -418,9 +422,9 @@ func TestEtcdadmConfigReconciler_PreEtcdadmCommandsWhenEtcdadmNotBuiltin(t *test
 
 	configKey := client.ObjectKeyFromObject(config)
 	g.Expect(myclient.Get(context.TODO(), configKey, config)).To(Succeed())
-	c := v1beta1conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
 	g.Expect(c).ToNot(BeNil())
-	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
 }
 
 // If Init lock is already acquired but cluster status does not show etcd initialized and another etcdadmConfig is created, requeue it
@@ -457,7 +461,7 @@ func TestEtcdadmConfigReconciler_RequeueIfInitLockIsAquired(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(result.Requeue).To(BeFalse())
 	g.Expect(result.RequeueAfter).To(Equal(30 * time.Second))
-	c := v1beta1conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
 	g.Expect(c).To(BeNil())
 }
 
@@ -511,7 +515,7 @@ func TestEtcdadmConfigReconciler_JoinMemberIfEtcdIsInitialized_CloudInit(t *test
 	machine := newMachine(cluster, "machine")
 	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
 
-	etcdCACerts := etcdCACertKeyPair()
+	etcdCACerts := render.EtcdCACertificates()
 	g.Expect(etcdCACerts.Generate()).To(Succeed())
 	etcdCASecret := etcdCACerts[0].AsSecret(client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
 
@@ -546,9 +550,9 @@ func TestEtcdadmConfigReconciler_JoinMemberIfEtcdIsInitialized_CloudInit(t *test
 
 	configKey := client.ObjectKeyFromObject(config)
 	g.Expect(myclient.Get(context.TODO(), configKey, config)).To(Succeed())
-	c := v1beta1conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
 	g.Expect(c).ToNot(BeNil())
-	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
 
 	bootstrapSecret := &corev1.Secret{}
 	err = myclient.Get(ctx, configKey, bootstrapSecret)
@@ -572,7 +576,7 @@ func TestEtcdadmConfigReconciler_JoinMemberIfEtcdIsInitialized_Bottlerocket(t *t
 	machine := newMachine(cluster, "machine")
 	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.Bottlerocket)
 
-	etcdCACerts := etcdCACertKeyPair()
+	etcdCACerts := render.EtcdCACertificates()
 	g.Expect(etcdCACerts.Generate()).To(Succeed())
 	etcdCASecret := etcdCACerts[0].AsSecret(client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
 
@@ -607,9 +611,9 @@ func TestEtcdadmConfigReconciler_JoinMemberIfEtcdIsInitialized_Bottlerocket(t *t
 
 	configKey := client.ObjectKeyFromObject(config)
 	g.Expect(myclient.Get(context.TODO(), configKey, config)).To(Succeed())
-	c := v1beta1conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
 	g.Expect(c).ToNot(BeNil())
-	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
 
 	bootstrapSecret := &corev1.Secret{}
 	err = myclient.Get(ctx, configKey, bootstrapSecret)
@@ -632,7 +636,7 @@ func TestEtcdadmConfigReconciler_JoinMemberIfEtcdIsInitialized_EtcdInitSecretOld
 	machine := newMachine(cluster, "machine")
 	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
 
-	etcdCACerts := etcdCACertKeyPair()
+	etcdCACerts := render.EtcdCACertificates()
 	err := etcdCACerts.Generate()
 	g.Expect(err).NotTo(HaveOccurred())
 	etcdCASecret := etcdCACerts[0].AsSecret(client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
@@ -668,9 +672,9 @@ func TestEtcdadmConfigReconciler_JoinMemberIfEtcdIsInitialized_EtcdInitSecretOld
 
 	configKey := client.ObjectKeyFromObject(config)
 	g.Expect(myclient.Get(context.TODO(), configKey, config)).To(Succeed())
-	c := v1beta1conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
 	g.Expect(c).ToNot(BeNil())
-	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Status).To(Equal(metav1.ConditionTrue))
 
 	bootstrapSecret := &corev1.Secret{}
 	err = myclient.Get(ctx, configKey, bootstrapSecret)
@@ -680,6 +684,702 @@ func TestEtcdadmConfigReconciler_JoinMemberIfEtcdIsInitialized_EtcdInitSecretOld
 	g.Expect(joinData).To(ContainSubstring("etcdadm join https://1.2.3.4:2379 --init-system systemd"))
 }
 
+// Backups to an object store render a systemd timer and the credentials read from the referenced secret
+func TestEtcdadmConfigReconciler_InitializeEtcdWithS3Backup_CloudInit(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Spec.Backup = &etcdbootstrapv1.BackupConfiguration{
+		Retention: 5,
+		S3: &etcdbootstrapv1.S3BackupConfiguration{
+			Endpoint:             "http://minio:9000",
+			Bucket:               "etcd-backups",
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "minio-credentials"},
+		},
+	}
+	backupSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "minio-credentials",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"accessKeyID":     []byte("minioadmin"),
+			"secretAccessKey": []byte("miniosecret"),
+		},
+	}
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+		backupSecret,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bootstrapSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), bootstrapSecret)).To(Succeed())
+	initData := string(bootstrapSecret.Data["value"])
+	g.Expect(initData).To(ContainSubstring("path: /etc/systemd/system/etcd-backup.timer"))
+	g.Expect(initData).To(ContainSubstring(`S3_ENDPOINT="http://minio:9000"`))
+	g.Expect(initData).To(ContainSubstring(`AWS_SECRET_ACCESS_KEY="miniosecret"`))
+	g.Expect(initData).To(ContainSubstring("- systemctl enable --now etcd-backup.timer"))
+}
+
+// Reconcile fails instead of rendering backups without credentials when the referenced secret is missing
+func TestEtcdadmConfigReconciler_InitializeEtcdWithS3BackupSecretNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Spec.Backup = &etcdbootstrapv1.BackupConfiguration{
+		S3: &etcdbootstrapv1.S3BackupConfiguration{
+			Endpoint:             "http://minio:9000",
+			Bucket:               "etcd-backups",
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "minio-credentials"},
+		},
+	}
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	locker := &etcdInitLocker{}
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: locker,
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("minio-credentials"))
+	g.Expect(locker.locked).To(BeFalse())
+}
+
+// The init machine restores a snapshot stored in a secret before etcdadm starts the member
+func TestEtcdadmConfigReconciler_InitializeEtcdRestoreFromSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Spec.RestoreFrom = &etcdbootstrapv1.RestoreConfiguration{
+		SecretRef: &etcdbootstrapv1.SnapshotSourceReference{Name: "etcd-snapshot"},
+	}
+	snapshotSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "etcd-snapshot",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"snapshot.db": []byte("snapshot"),
+		},
+	}
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+		snapshotSecret,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bootstrapSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), bootstrapSecret)).To(Succeed())
+	initData := string(bootstrapSecret.Data["value"])
+	g.Expect(initData).To(ContainSubstring("path: /var/lib/etcd-restore/snapshot.db"))
+	g.Expect(initData).To(ContainSubstring("c25hcHNob3Q="))
+	g.Expect(initData).To(ContainSubstring("etcdadm init --init-system systemd --snapshot /var/lib/etcd-restore/snapshot.db"))
+}
+
+// Snapshots read from a Secret are embedded in the bootstrap data, which has to fit in the user data of the machine
+func TestEtcdadmConfigReconciler_InitializeEtcdRestoreSnapshotTooLarge(t *testing.T) {
+	g := NewWithT(t)
+
+	// the user data limit of EC2 instances
+	const maxSize = 16 * 1024
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Spec.RestoreFrom = &etcdbootstrapv1.RestoreConfiguration{
+		SecretRef: &etcdbootstrapv1.SnapshotSourceReference{Name: "etcd-snapshot"},
+	}
+	snapshotSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "etcd-snapshot",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"snapshot.db": make([]byte, maxSize),
+		},
+	}
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+		snapshotSecret,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	locker := &etcdInitLocker{}
+	k := &EtcdadmConfigReconciler{
+		Log:                          log.Log,
+		Client:                       myclient,
+		EtcdadmInitLock:              locker,
+		MaxSnapshotBootstrapDataSize: maxSize,
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("restore from a url instead"))
+	g.Expect(locker.locked).To(BeFalse())
+
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	g.Expect(c).ToNot(BeNil())
+	g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(c.Reason).To(Equal(etcdbootstrapv1.RestoreSnapshotTooLargeReason))
+	g.Expect(config.Status.Initialization.DataSecretCreated).To(BeNil())
+	err = myclient.Get(ctx, client.ObjectKeyFromObject(config), &corev1.Secret{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+// Snapshots downloaded from a url are verified against their checksum before they are restored
+func TestEtcdadmConfigReconciler_InitializeEtcdRestoreFromURL(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Spec.RestoreFrom = &etcdbootstrapv1.RestoreConfiguration{
+		URL:    "https://backups.example.com/snapshot.db",
+		SHA256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bootstrapSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), bootstrapSecret)).To(Succeed())
+	initData := string(bootstrapSecret.Data["value"])
+	g.Expect(initData).To(ContainSubstring("https://backups.example.com/snapshot.db"))
+	g.Expect(initData).To(ContainSubstring("sha256sum --check --strict"))
+	g.Expect(initData).To(ContainSubstring("--snapshot /var/lib/etcd-restore/snapshot.db"))
+}
+
+// Members joining a restored cluster join it normally
+func TestEtcdadmConfigReconciler_JoinMemberIgnoresRestoreFrom(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	cluster.Status.ManagedExternalEtcdInitialized = true
+	conditions.Set(cluster, metav1.Condition{
+		Type:   string(clusterv1.ManagedExternalEtcdClusterInitializedCondition),
+		Status: metav1.ConditionTrue,
+	})
+	etcdInitSecret := newEtcdInitSecret(cluster)
+
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Spec.RestoreFrom = &etcdbootstrapv1.RestoreConfiguration{
+		SecretRef: &etcdbootstrapv1.SnapshotSourceReference{Name: "etcd-snapshot"},
+	}
+
+	etcdCACerts := render.EtcdCACertificates()
+	g.Expect(etcdCACerts.Generate()).To(Succeed())
+	etcdCASecret := etcdCACerts[0].AsSecret(client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		etcdInitSecret,
+		etcdCASecret,
+		config,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bootstrapSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), bootstrapSecret)).To(Succeed())
+	joinData := string(bootstrapSecret.Data["value"])
+	g.Expect(joinData).To(ContainSubstring("etcdadm join https://1.2.3.4:2379 --init-system systemd"))
+	g.Expect(joinData).NotTo(ContainSubstring("--snapshot"))
+}
+
+func TestEtcdadmConfigReconciler_JoinMemberAsLearner(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	cluster.Status.ManagedExternalEtcdInitialized = true
+	conditions.Set(cluster, metav1.Condition{
+		Type:   string(clusterv1.ManagedExternalEtcdClusterInitializedCondition),
+		Status: metav1.ConditionTrue,
+	})
+	etcdInitSecret := newEtcdInitSecret(cluster)
+
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Spec.JoinAsLearner = true
+
+	etcdCACerts := render.EtcdCACertificates()
+	g.Expect(etcdCACerts.Generate()).To(Succeed())
+	etcdCASecret := etcdCACerts[0].AsSecret(client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		etcdInitSecret,
+		etcdCASecret,
+		config,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bootstrapSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), bootstrapSecret)).To(Succeed())
+	joinData := string(bootstrapSecret.Data["value"])
+	g.Expect(joinData).To(ContainSubstring("/etc/etcd/learner/etcd-learner.sh https://1.2.3.4:2379 "))
+	g.Expect(joinData).To(ContainSubstring("etcdadm join https://1.2.3.4:2379 --init-system systemd"))
+}
+
+func TestEtcdadmConfigReconciler_JoinMemberWithAutoNoProxy(t *testing.T) {
+	tests := []struct {
+		name   string
+		format etcdbootstrapv1.Format
+		want   string
+	}{
+		{
+			name:   "cloud-config",
+			format: etcdbootstrapv1.CloudConfig,
+			want:   "no_proxy='localhost,192.168.0.0/16,10.96.0.0/12,api.example.com,1.2.3.4,10.0.0.5,127.0.0.1'",
+		},
+		{
+			name:   "bottlerocket",
+			format: etcdbootstrapv1.Bottlerocket,
+			want:   `no-proxy = ["localhost", "192.168.0.0/16", "10.96.0.0/12", "api.example.com", "1.2.3.4", "10.0.0.5"]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := newCluster("external-etcd-cluster")
+			cluster.Spec.ClusterNetwork.Pods.CIDRBlocks = []string{"192.168.0.0/16"}
+			cluster.Spec.ClusterNetwork.Services.CIDRBlocks = []string{"10.96.0.0/12"}
+			cluster.Spec.ControlPlaneEndpoint.Host = "api.example.com"
+			cluster.Status.ManagedExternalEtcdInitialized = true
+			conditions.Set(cluster, metav1.Condition{
+				Type:   string(clusterv1.ManagedExternalEtcdClusterInitializedCondition),
+				Status: metav1.ConditionTrue,
+			})
+			etcdInitSecret := newEtcdInitSecret(cluster)
+
+			member := newMachine(cluster, "member")
+			member.Spec.Bootstrap.ConfigRef.Name = "member"
+			member.Status.Addresses = clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineInternalIP, Address: "1.2.3.4"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.5"},
+			}
+			machine := newMachine(cluster, "machine")
+			config := newEtcdadmConfig(machine, "etcdadmConfig", tt.format)
+			config.Spec.Proxy = &etcdbootstrapv1.ProxyConfiguration{
+				HTTPSProxy:  "http://proxy:3128",
+				NoProxy:     []string{"localhost"},
+				AutoNoProxy: true,
+			}
+
+			etcdCACerts := render.EtcdCACertificates()
+			g.Expect(etcdCACerts.Generate()).To(Succeed())
+			etcdCASecret := etcdCACerts[0].AsSecret(client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
+
+			objects := []client.Object{
+				cluster,
+				member,
+				machine,
+				etcdInitSecret,
+				etcdCASecret,
+				config,
+			}
+			myclient := fake.NewClientBuilder().
+				WithScheme(setupScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+				Build()
+
+			k := &EtcdadmConfigReconciler{
+				Log:             log.Log,
+				Client:          myclient,
+				EtcdadmInitLock: &etcdInitLocker{},
+			}
+			request := ctrl.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: "default",
+					Name:      "etcdadmConfig",
+				},
+			}
+			_, err := k.Reconcile(ctx, request)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			bootstrapSecret := &corev1.Secret{}
+			g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), bootstrapSecret)).To(Succeed())
+			g.Expect(string(bootstrapSecret.Data["value"])).To(ContainSubstring(tt.want))
+		})
+	}
+}
+
+// The registry credentials are read from the Secret the config references, in either key/value or docker config form
+func TestEtcdadmConfigReconciler_InitializeEtcdWithRegistryCredentialsRef(t *testing.T) {
+	tests := []struct {
+		name   string
+		ref    etcdbootstrapv1.RegistryCredentialsReference
+		secret *corev1.Secret
+		want   string
+	}{
+		{
+			name: "custom keys",
+			ref:  etcdbootstrapv1.RegistryCredentialsReference{Name: "mirror-credentials", UsernameKey: "user", PasswordKey: "token"},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mirror-credentials"},
+				Data: map[string][]byte{
+					"user":  []byte("robot"),
+					"token": []byte("s3cr3t"),
+				},
+			},
+			want: "registry = \"mirror.example.com\"\nusername = \"robot\"\npassword = \"s3cr3t\"",
+		},
+		{
+			name: "docker config",
+			ref:  etcdbootstrapv1.RegistryCredentialsReference{Name: "mirror-credentials"},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mirror-credentials"},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{
+					corev1.DockerConfigJsonKey: []byte(`{"auths":{"https://mirror.example.com/v2/":{"auth":"` + base64.StdEncoding.EncodeToString([]byte("robot:s3cr3t")) + `"}}}`),
+				},
+			},
+			want: "registry = \"mirror.example.com\"\nusername = \"robot\"\npassword = \"s3cr3t\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := newCluster("external-etcd-cluster")
+			machine := newMachine(cluster, "machine")
+			config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.Bottlerocket)
+			config.Spec.RegistryMirror = &etcdbootstrapv1.RegistryMirrorConfiguration{
+				Endpoint:       "mirror.example.com",
+				CACert:         "-----BEGIN CERTIFICATE-----\nbWlycm9y\n-----END CERTIFICATE-----\n",
+				CredentialsRef: &tt.ref,
+			}
+
+			objects := []client.Object{
+				cluster,
+				machine,
+				config,
+				tt.secret,
+			}
+			myclient := fake.NewClientBuilder().
+				WithScheme(setupScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+				Build()
+
+			k := &EtcdadmConfigReconciler{
+				Log:             log.Log,
+				Client:          myclient,
+				EtcdadmInitLock: &etcdInitLocker{},
+			}
+			request := ctrl.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: "default",
+					Name:      "etcdadmConfig",
+				},
+			}
+			_, err := k.Reconcile(ctx, request)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			bootstrapSecret := &corev1.Secret{}
+			g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), bootstrapSecret)).To(Succeed())
+			g.Expect(string(bootstrapSecret.Data["value"])).To(ContainSubstring(tt.want))
+
+			g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+			g.Expect(conditions.IsTrue(config, etcdbootstrapv1.RegistryCredentialsAvailableCondition)).To(BeTrue())
+		})
+	}
+}
+
+// Without a credentials reference, the bootstrap data is rendered without credentials when the default Secret is
+// missing, and the condition reports it
+func TestEtcdadmConfigReconciler_InitializeEtcdWithoutRegistryCredentials(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.Bottlerocket)
+	config.Spec.RegistryMirror = &etcdbootstrapv1.RegistryMirrorConfiguration{
+		Endpoint: "mirror.example.com",
+	}
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bootstrapSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), bootstrapSecret)).To(Succeed())
+	g.Expect(string(bootstrapSecret.Data["value"])).NotTo(ContainSubstring("settings.container-registry.credentials"))
+
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+	g.Expect(conditions.IsTrue(config, etcdbootstrapv1.DataSecretAvailableCondition)).To(BeTrue())
+	c := conditions.Get(config, etcdbootstrapv1.RegistryCredentialsAvailableCondition)
+	g.Expect(c).ToNot(BeNil())
+	g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(c.Reason).To(Equal(etcdbootstrapv1.RegistryCredentialsNotFoundReason))
+	g.Expect(c.Message).To(ContainSubstring(registrySecretName))
+}
+
+// The bootstrap data waits for the registry credentials the config references
+func TestEtcdadmConfigReconciler_InitializeEtcdWithRegistryCredentialsRefNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.Bottlerocket)
+	config.Spec.RegistryMirror = &etcdbootstrapv1.RegistryMirrorConfiguration{
+		Endpoint:       "mirror.example.com",
+		CredentialsRef: &etcdbootstrapv1.RegistryCredentialsReference{Name: "mirror-credentials"},
+	}
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+	}
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+
+	locker := &etcdInitLocker{}
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: locker,
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: "default",
+			Name:      "etcdadmConfig",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("mirror-credentials"))
+	g.Expect(locker.locked).To(BeFalse())
+
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+	c := conditions.Get(config, etcdbootstrapv1.DataSecretAvailableCondition)
+	g.Expect(c).ToNot(BeNil())
+	g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(c.Reason).To(Equal(etcdbootstrapv1.RegistryCredentialsUnavailableReason))
+	g.Expect(conditions.GetReason(config, etcdbootstrapv1.RegistryCredentialsAvailableCondition)).To(Equal(etcdbootstrapv1.RegistryCredentialsUnavailableReason))
+	g.Expect(config.Status.Initialization.DataSecretCreated).To(BeNil())
+}
+
+func TestEtcdadmConfigReconciler_SecretToEtcdadmConfigs(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	waiting := newEtcdadmConfig(newMachine(cluster, "waiting"), "waiting", etcdbootstrapv1.Bottlerocket)
+	waiting.Spec.RegistryMirror = &etcdbootstrapv1.RegistryMirrorConfiguration{
+		CredentialsRef: &etcdbootstrapv1.RegistryCredentialsReference{Name: "mirror-credentials"},
+	}
+	ready := newEtcdadmConfig(newMachine(cluster, "ready"), "ready", etcdbootstrapv1.Bottlerocket)
+	ready.Spec.RegistryMirror = waiting.Spec.RegistryMirror.DeepCopy()
+	ready.Status.Initialization.DataSecretCreated = ptr.To(true)
+	legacy := newEtcdadmConfig(newMachine(cluster, "legacy"), "legacy", etcdbootstrapv1.Bottlerocket)
+	legacy.Spec.RegistryMirror = &etcdbootstrapv1.RegistryMirrorConfiguration{Endpoint: "mirror.example.com"}
+	noMirror := newEtcdadmConfig(newMachine(cluster, "no-mirror"), "no-mirror", etcdbootstrapv1.Bottlerocket)
+	backup := newEtcdadmConfig(newMachine(cluster, "backup"), "backup", etcdbootstrapv1.CloudConfig)
+	backup.Spec.Backup = &etcdbootstrapv1.BackupConfiguration{
+		S3: &etcdbootstrapv1.S3BackupConfiguration{
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "backup-credentials"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(waiting, ready, legacy, noMirror, backup).Build()
+	reconciler := &EtcdadmConfigReconciler{
+		Log:    log.Log,
+		Client: fakeClient,
+	}
+
+	configs := reconciler.SecretToEtcdadmConfigs(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mirror-credentials"},
+	})
+	g.Expect(configs).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(waiting)}))
+
+	configs = reconciler.SecretToEtcdadmConfigs(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: registrySecretName},
+	})
+	g.Expect(configs).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(legacy)}))
+
+	configs = reconciler.SecretToEtcdadmConfigs(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup-credentials"},
+	})
+	g.Expect(configs).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(backup)}))
+}
+
+// decodeBottlerocketUserData returns the decoded user data of all host and bootstrap containers
+func decodeBottlerocketUserData(g *WithT, settings string) string {
+	var decoded strings.Builder
+	for _, match := range regexp.MustCompile(`user-data = "([^"]*)"`).FindAllStringSubmatch(settings, -1) {
+		userData, err := base64.StdEncoding.DecodeString(match[1])
+		g.Expect(err).NotTo(HaveOccurred())
+		decoded.Write(userData)
+	}
+	return decoded.String()
+}
+
 // newCluster creates a CAPI Cluster object
 func newCluster(name string) *clusterv1.Cluster {
 	c := &clusterv1.Cluster{
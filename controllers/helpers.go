@@ -3,7 +3,10 @@ package controllers
 import (
 	"context"
 
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,3 +62,35 @@ func (r *EtcdadmConfigReconciler) ClusterToEtcdadmConfigs(ctx context.Context, o
 	}
 	return result
 }
+
+// SecretToEtcdadmConfigs is a handler.ToRequestsFunc to be used to enqueue
+// requests for reconciliation of the EtcdadmConfigs waiting for the registry
+// or backup credentials in a Secret. Configs whose bootstrap data Secret was
+// already created are skipped, so rotating credentials does not re-render them.
+func (r *EtcdadmConfigReconciler) SecretToEtcdadmConfigs(ctx context.Context, o client.Object) []ctrl.Request {
+	var result []ctrl.Request
+
+	s, ok := o.(*corev1.Secret)
+	if !ok {
+		r.Log.Error(errors.Errorf("expected a Secret but got a %T", o.GetObjectKind()), "failed to get EtcdadmConfigs for Secret")
+		return nil
+	}
+
+	configList := &etcdbootstrapv1.EtcdadmConfigList{}
+	if err := r.Client.List(ctx, configList, client.InNamespace(s.Namespace)); err != nil {
+		r.Log.Error(err, "failed to list EtcdadmConfigs", "Secret", s.Name, "Namespace", s.Namespace)
+		return nil
+	}
+
+	for i := range configList.Items {
+		c := &configList.Items[i]
+		if ptr.Deref(c.Status.Initialization.DataSecretCreated, false) {
+			continue
+		}
+		if c.Spec.RegistryMirror != nil && registryCredentialsSecretName(c) == s.Name ||
+			c.Spec.Backup != nil && c.Spec.Backup.S3 != nil && c.Spec.Backup.S3.CredentialsSecretRef.Name == s.Name {
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(c)})
+		}
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// renderSpec returns the spec the bootstrap data of scope is rendered from. When the spec opts in to AutoNoProxy, the
+// addresses etcd machines reach directly are appended to its NoProxy entries, after the ones set by the user.
+func (r *EtcdadmConfigReconciler) renderSpec(ctx context.Context, scope *Scope, joinAddress string) (etcdbootstrapv1.EtcdadmConfigSpec, error) {
+	spec := scope.Config.Spec
+	if spec.Proxy == nil || !spec.Proxy.AutoNoProxy {
+		return spec, nil
+	}
+
+	machines, err := r.etcdMachines(ctx, scope.Cluster)
+	if err != nil {
+		return spec, err
+	}
+	spec = *spec.DeepCopy()
+	for _, entry := range clusterNoProxy(scope.Cluster, joinAddress, machines) {
+		if !slices.Contains(spec.Proxy.NoProxy, entry) {
+			spec.Proxy.NoProxy = append(spec.Proxy.NoProxy, entry)
+		}
+	}
+	return spec, nil
+}
+
+// clusterNoProxy returns the pod and service CIDRs and the control plane endpoint of cluster, the hosts of the client
+// URLs in joinAddress and the addresses of the etcd machines.
+func clusterNoProxy(cluster *clusterv1.Cluster, joinAddress string, machines []clusterv1.Machine) []string {
+	var noProxy []string
+	noProxy = append(noProxy, cluster.Spec.ClusterNetwork.Pods.CIDRBlocks...)
+	noProxy = append(noProxy, cluster.Spec.ClusterNetwork.Services.CIDRBlocks...)
+	if host := cluster.Spec.ControlPlaneEndpoint.Host; host != "" {
+		noProxy = append(noProxy, host)
+	}
+	noProxy = append(noProxy, userdata.JoinAddressHosts(joinAddress)...)
+	for i := range machines {
+		noProxy = append(noProxy, machineHosts(&machines[i])...)
+	}
+	return noProxy
+}
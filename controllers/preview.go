@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	redactedPreviewCAKey      = "<redacted: etcd CA key>"
+	redactedPreviewCredential = "<redacted>"
+	// previewErrorKey holds the error rendering the preview in the preview Secret, in place of the bootstrap data.
+	previewErrorKey = "error"
+	// placeholderPreviewCACert stands in for the etcd CA until it is generated by the machine initializing the cluster,
+	// so that previews do not create it and stay the same between reconciles.
+	placeholderPreviewCACert = "<etcd CA certificate, generated when the etcd cluster is initialized>"
+)
+
+// previewSecretName returns the name of the Secret the bootstrap data of config is previewed in.
+func previewSecretName(config *etcdbootstrapv1.EtcdadmConfig) string {
+	return config.Name + "-preview"
+}
+
+// reconcilePreview renders the bootstrap data of an EtcdadmConfig carrying the preview annotation into its preview
+// Secret, from its current spec. It neither marks the config ready nor takes the init lock, and it is rendered whether
+// or not the bootstrap data of the Machine has been generated, so that changes to ready configs can be previewed too.
+// Failures to render the preview are recorded in the preview Secret rather than on the config.
+func (r *EtcdadmConfigReconciler) reconcilePreview(ctx context.Context, scope *Scope) error {
+	log := scope.Logger
+	specHash, err := hashEtcdadmConfigSpec(scope.Config.Spec)
+	if err != nil {
+		return err
+	}
+
+	data, err := r.renderPreview(ctx, scope)
+	if err != nil {
+		log.Info("Failed to render bootstrap data preview", "secret", previewSecretName(scope.Config), "error", err.Error())
+		return r.storePreview(ctx, scope, map[string][]byte{previewErrorKey: []byte(err.Error())}, specHash)
+	}
+	if err := r.storePreview(ctx, scope, map[string][]byte{"value": data}, specHash); err != nil {
+		return err
+	}
+	log.Info("Rendered bootstrap data preview", "secret", previewSecretName(scope.Config), "specHash", specHash)
+	return nil
+}
+
+// renderPreview returns the bootstrap data of scope.Config with its credentials redacted. It renders a copy of the
+// config, so that resolving the credentials does not set its conditions.
+func (r *EtcdadmConfigReconciler) renderPreview(ctx context.Context, scope *Scope) ([]byte, error) {
+	previewScope := *scope
+	previewScope.Config = scope.Config.DeepCopy()
+	scope = &previewScope
+	log := scope.Logger
+
+	etcdCerts := render.EtcdCACertificates()
+	if err := etcdCerts.Lookup(ctx, r.Client, util.ObjectKey(scope.Cluster)); err != nil {
+		return nil, errors.Wrap(err, "failed doing a lookup for the etcd CA")
+	}
+	redactPreviewCertificates(etcdCerts)
+
+	if !conditions.IsTrue(scope.Cluster, string(clusterv1.ManagedExternalEtcdClusterInitializedCondition)) {
+		initInput, err := r.newInitInput(ctx, scope, etcdCerts)
+		if err != nil {
+			return nil, err
+		}
+		redactPreviewCredentials(&initInput.BaseUserData)
+		spec, err := r.renderSpec(ctx, scope, "")
+		if err != nil {
+			return nil, err
+		}
+		data, err := render.Init(initInput, spec, log)
+		return data, errors.Wrap(err, "failed to render init bootstrap data preview")
+	}
+
+	joinAddress, err := r.resolveJoinAddress(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	joinInput, err := r.newJoinInput(ctx, scope, joinAddress, etcdCerts)
+	if err != nil {
+		return nil, err
+	}
+	redactPreviewCredentials(&joinInput.BaseUserData)
+	spec, err := r.renderSpec(ctx, scope, joinAddress)
+	if err != nil {
+		return nil, err
+	}
+	data, err := render.Join(joinInput, spec, log)
+	return data, errors.Wrap(err, "failed to render join bootstrap data preview")
+}
+
+// storePreview creates or updates the preview Secret of scope.Config with data, which holds either the bootstrap data
+// or the error rendering it.
+func (r *EtcdadmConfigReconciler) storePreview(ctx context.Context, scope *Scope, data map[string][]byte, specHash string) error {
+	config := scope.Config
+	key := client.ObjectKey{Namespace: config.Namespace, Name: previewSecretName(config)}
+	labels := map[string]string{
+		clusterv1.ClusterNameLabel:           scope.Cluster.Name,
+		etcdbootstrapv1.PreviewSpecHashLabel: specHash,
+	}
+
+	existing := &corev1.Secret{}
+	if err := r.Client.Get(ctx, key, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to retrieve Secret %q", key)
+		}
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels:    labels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: etcdbootstrapv1.GroupVersion.String(),
+						Kind:       "EtcdadmConfig",
+						Name:       config.Name,
+						UID:        config.UID,
+						Controller: ptr.To(true),
+					},
+				},
+			},
+			Data: data,
+			Type: corev1.SecretTypeOpaque,
+		}
+		if err := r.Client.Create(ctx, s); err != nil {
+			return errors.Wrapf(err, "failed to create Secret %q", key)
+		}
+		return nil
+	}
+
+	if existing.Labels[etcdbootstrapv1.PreviewSpecHashLabel] == specHash && maps.EqualFunc(existing.Data, data, bytes.Equal) {
+		return nil
+	}
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		existing.Labels[k] = v
+	}
+	existing.Data = data
+	if err := r.Client.Update(ctx, existing); err != nil {
+		return errors.Wrapf(err, "failed to update Secret %q", key)
+	}
+	return nil
+}
+
+// redactPreviewCertificates replaces the private keys of etcdCerts with a marker, and the ones not generated yet
+// with placeholders.
+func redactPreviewCertificates(etcdCerts secret.Certificates) {
+	for _, c := range etcdCerts {
+		cert := []byte(placeholderPreviewCACert)
+		if c.KeyPair != nil {
+			cert = c.KeyPair.Cert
+		}
+		c.KeyPair = &certs.KeyPair{
+			Cert: cert,
+			Key:  []byte(redactedPreviewCAKey),
+		}
+	}
+}
+
+// redactPreviewCredentials replaces the credentials input references with a marker: registry passwords, the secret key
+// of etcd backups and the passwords of users, as redacted by etcdadm-bootstrap inspect.
+func redactPreviewCredentials(input *userdata.BaseUserData) {
+	if input.RegistryMirrorCredentials.Password != "" {
+		input.RegistryMirrorCredentials.Password = redactedPreviewCredential
+	}
+	registries := make(map[string]userdata.RegistryCredentials, len(input.RegistryMirrorCredentials.Registries))
+	for registry, credentials := range input.RegistryMirrorCredentials.Registries {
+		if credentials.Password != "" {
+			credentials.Password = redactedPreviewCredential
+		}
+		registries[registry] = credentials
+	}
+	if input.RegistryMirrorCredentials.Registries != nil {
+		input.RegistryMirrorCredentials.Registries = registries
+	}
+	if input.BackupCredentials.SecretAccessKey != "" {
+		input.BackupCredentials.SecretAccessKey = redactedPreviewCredential
+	}
+	users := make([]bootstrapv1.User, len(input.Users))
+	for i, user := range input.Users {
+		if user.Passwd != nil {
+			user.Passwd = ptr.To(redactedPreviewCredential)
+		}
+		users[i] = user
+	}
+	if input.Users != nil {
+		input.Users = users
+	}
+}
+
+// hashEtcdadmConfigSpec returns a short hash of spec, for reviewers to tell which spec a preview was rendered from.
+func hashEtcdadmConfigSpec(spec etcdbootstrapv1.EtcdadmConfigSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal EtcdadmConfigSpec")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:32], nil
+}
@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"testing"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/render"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestEtcdadmConfigReconciler_Preview(t *testing.T) {
+	tests := []struct {
+		name               string
+		clusterInitialized bool
+		ready              bool
+		wantContains       []string
+	}{
+		{
+			name:         "previews init bootstrap data before the etcd CA is created",
+			wantContains: []string{"etcdadm init", placeholderPreviewCACert, redactedPreviewCAKey},
+		},
+		{
+			name:               "previews join bootstrap data with the etcd CA key redacted",
+			clusterInitialized: true,
+			wantContains:       []string{"etcdadm join https://1.2.3.4:2379", "BEGIN CERTIFICATE", redactedPreviewCAKey},
+		},
+		{
+			name:               "previews bootstrap data of ready configs",
+			clusterInitialized: true,
+			ready:              true,
+			wantContains:       []string{"etcdadm join https://1.2.3.4:2379", "BEGIN CERTIFICATE", redactedPreviewCAKey},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := newCluster("external-etcd-cluster")
+			machine := newMachine(cluster, "machine")
+			config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+			config.Annotations = map[string]string{etcdbootstrapv1.PreviewAnnotation: ""}
+			if tt.ready {
+				config.Status.Initialization.DataSecretCreated = ptr.To(true)
+			}
+			objects := []client.Object{cluster, machine, config}
+
+			etcdCACerts := render.EtcdCACertificates()
+			if tt.clusterInitialized {
+				conditions.Set(cluster, metav1.Condition{
+					Type:   string(clusterv1.ManagedExternalEtcdClusterInitializedCondition),
+					Status: metav1.ConditionTrue,
+				})
+				g.Expect(etcdCACerts.Generate()).To(Succeed())
+				etcdCASecret := etcdCACerts[0].AsSecret(client.ObjectKeyFromObject(cluster), *metav1.NewControllerRef(config, etcdbootstrapv1.GroupVersion.WithKind("EtcdadmConfig")))
+				objects = append(objects, newEtcdInitSecret(cluster), etcdCASecret)
+			}
+			myclient := fake.NewClientBuilder().
+				WithScheme(setupScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+				Build()
+
+			k := &EtcdadmConfigReconciler{
+				Log:             log.Log,
+				Client:          myclient,
+				EtcdadmInitLock: &etcdInitLocker{},
+			}
+			request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)}
+			_, err := k.Reconcile(ctx, request)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			// the preview does not hold back the bootstrap data of the Machine
+			g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+			g.Expect(ptr.Deref(config.Status.Initialization.DataSecretCreated, false)).To(BeTrue())
+			if !tt.ready {
+				g.Expect(config.Status.DataSecretName).To(HaveValue(Equal(config.Name)))
+				g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), &corev1.Secret{})).To(Succeed())
+			}
+
+			preview := &corev1.Secret{}
+			g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: config.Namespace, Name: "etcdadmConfig-preview"}, preview)).To(Succeed())
+			specHash, err := hashEtcdadmConfigSpec(config.Spec)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(preview.Labels).To(HaveKeyWithValue(etcdbootstrapv1.PreviewSpecHashLabel, specHash))
+			g.Expect(preview.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, cluster.Name))
+			g.Expect(preview.OwnerReferences).To(HaveLen(1))
+			g.Expect(preview.OwnerReferences[0].Name).To(Equal(config.Name))
+			previewData := string(preview.Data["value"])
+			for _, want := range tt.wantContains {
+				g.Expect(previewData).To(ContainSubstring(want))
+			}
+			g.Expect(previewData).NotTo(ContainSubstring("PRIVATE KEY"))
+
+			// changing the spec of the now ready config updates the preview and its hash
+			config.Spec.CloudInitConfig.Version = "v3.5.10"
+			g.Expect(myclient.Update(ctx, config)).To(Succeed())
+			_, err = k.Reconcile(ctx, request)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(preview), preview)).To(Succeed())
+			g.Expect(preview.Labels[etcdbootstrapv1.PreviewSpecHashLabel]).NotTo(Equal(specHash))
+			g.Expect(string(preview.Data["value"])).To(ContainSubstring("--version v3.5.10"))
+		})
+	}
+}
+
+func TestEtcdadmConfigReconciler_PreviewRedactsCredentials(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Annotations = map[string]string{etcdbootstrapv1.PreviewAnnotation: ""}
+	config.Status.Initialization.DataSecretCreated = ptr.To(true)
+	config.Spec.Users = []bootstrapv1.User{{Name: "etcd", Passwd: ptr.To("user-password")}}
+	config.Spec.RegistryMirror = &etcdbootstrapv1.RegistryMirrorConfiguration{
+		Endpoint:       "mirror.example.com",
+		CredentialsRef: &etcdbootstrapv1.RegistryCredentialsReference{Name: "mirror-credentials"},
+	}
+	config.Spec.Backup = &etcdbootstrapv1.BackupConfiguration{
+		S3: &etcdbootstrapv1.S3BackupConfiguration{
+			Endpoint:             "https://s3.us-west-2.amazonaws.com",
+			Bucket:               "etcd-backups",
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "backup-credentials"},
+		},
+	}
+	mirrorCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.Namespace, Name: "mirror-credentials"},
+		Data:       map[string][]byte{"username": []byte("mirror-user"), "password": []byte("mirror-password")},
+	}
+	backupCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.Namespace, Name: "backup-credentials"},
+		Data:       map[string][]byte{"accessKeyID": []byte("access-key"), "secretAccessKey": []byte("backup-secret-key")},
+	}
+
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, machine, config, mirrorCredentials, backupCredentials).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+	_, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	preview := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: config.Namespace, Name: "etcdadmConfig-preview"}, preview)).To(Succeed())
+	previewData := string(preview.Data["value"])
+	g.Expect(previewData).To(ContainSubstring("access-key"))
+	g.Expect(previewData).To(ContainSubstring(redactedPreviewCredential))
+	g.Expect(previewData).NotTo(ContainSubstring("mirror-password"))
+	g.Expect(previewData).NotTo(ContainSubstring("backup-secret-key"))
+	g.Expect(previewData).NotTo(ContainSubstring("user-password"))
+
+	// rendering the preview does not set the conditions of the config
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+	g.Expect(conditions.Get(config, etcdbootstrapv1.RegistryCredentialsAvailableCondition)).To(BeNil())
+}
+
+func TestEtcdadmConfigReconciler_PreviewError(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("external-etcd-cluster")
+	machine := newMachine(cluster, "machine")
+	config := newEtcdadmConfig(machine, "etcdadmConfig", etcdbootstrapv1.CloudConfig)
+	config.Annotations = map[string]string{etcdbootstrapv1.PreviewAnnotation: ""}
+	config.Status.Initialization.DataSecretCreated = ptr.To(true)
+	config.Spec.RegistryMirror = &etcdbootstrapv1.RegistryMirrorConfiguration{
+		Endpoint:       "mirror.example.com",
+		CredentialsRef: &etcdbootstrapv1.RegistryCredentialsReference{Name: "mirror-credentials"},
+	}
+
+	myclient := fake.NewClientBuilder().
+		WithScheme(setupScheme()).
+		WithObjects(cluster, machine, config).
+		WithStatusSubresource(&etcdbootstrapv1.EtcdadmConfig{}).
+		Build()
+	k := &EtcdadmConfigReconciler{
+		Log:             log.Log,
+		Client:          myclient,
+		EtcdadmInitLock: &etcdInitLocker{},
+	}
+	_, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	preview := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: config.Namespace, Name: "etcdadmConfig-preview"}, preview)).To(Succeed())
+	g.Expect(preview.Data).NotTo(HaveKey("value"))
+	g.Expect(string(preview.Data[previewErrorKey])).To(ContainSubstring("mirror-credentials"))
+
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+	g.Expect(conditions.Get(config, etcdbootstrapv1.RegistryCredentialsAvailableCondition)).To(BeNil())
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+)
+
+// dockerConfigJSON is the content of the .dockerconfigjson key of kubernetes.io/dockerconfigjson Secrets.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+type dockerConfigAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Auth is the base64 encoded "username:password", set instead of Username and Password.
+	Auth string `json:"auth,omitempty"`
+}
+
+// registryCredentialsSecretName returns the name of the Secret holding the registry credentials of config.
+func registryCredentialsSecretName(config *etcdbootstrapv1.EtcdadmConfig) string {
+	if ref := config.Spec.RegistryMirror.CredentialsRef; ref != nil {
+		return ref.Name
+	}
+	return registrySecretName
+}
+
+// resolveRegistryCredentials reads the registry mirror credentials of config from the Secret it references, or from the
+// "registry-credentials" Secret if it references none.
+func (r *EtcdadmConfigReconciler) resolveRegistryCredentials(ctx context.Context, config *etcdbootstrapv1.EtcdadmConfig) (userdata.RegistryMirrorCredentials, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: config.Namespace, Name: registryCredentialsSecretName(config)}
+	if err := r.Client.Get(ctx, key, secret); err != nil {
+		return userdata.RegistryMirrorCredentials{}, errors.Wrapf(err, "failed to retrieve Secret %q", key)
+	}
+
+	if secret.Type == corev1.SecretTypeDockerConfigJson {
+		registries, err := parseDockerConfigJSON(secret.Data[corev1.DockerConfigJsonKey])
+		if err != nil {
+			return userdata.RegistryMirrorCredentials{}, errors.Wrapf(err, "secret %q is not a valid docker config", key)
+		}
+		return userdata.RegistryMirrorCredentials{Registries: registries}, nil
+	}
+
+	usernameKey, passwordKey := registryUsernameKey, registryPasswordKey
+	if ref := config.Spec.RegistryMirror.CredentialsRef; ref != nil {
+		if ref.UsernameKey != "" {
+			usernameKey = ref.UsernameKey
+		}
+		if ref.PasswordKey != "" {
+			passwordKey = ref.PasswordKey
+		}
+	}
+	username, ok := secret.Data[usernameKey]
+	if !ok {
+		return userdata.RegistryMirrorCredentials{}, errors.Errorf("secret %q is missing key %q", key, usernameKey)
+	}
+	password, ok := secret.Data[passwordKey]
+	if !ok {
+		return userdata.RegistryMirrorCredentials{}, errors.Errorf("secret %q is missing key %q", key, passwordKey)
+	}
+	return userdata.RegistryMirrorCredentials{
+		Username: string(username),
+		Password: string(password),
+	}, nil
+}
+
+// parseDockerConfigJSON returns the credentials of each registry of a docker config, keyed by registry host.
+func parseDockerConfigJSON(data []byte) (map[string]userdata.RegistryCredentials, error) {
+	config := dockerConfigJSON{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	registries := make(map[string]userdata.RegistryCredentials, len(config.Auths))
+	for registry, auth := range config.Auths {
+		if auth.Username == "" && auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode the auth of registry %q", registry)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, errors.Errorf("the auth of registry %q is not username:password", registry)
+			}
+			auth.Username, auth.Password = username, password
+		}
+		registries[registryHost(registry)] = userdata.RegistryCredentials{
+			Username: auth.Username,
+			Password: auth.Password,
+		}
+	}
+	return registries, nil
+}
+
+// registryHost returns the host of a docker config registry key, which may also be a URL such as
+// "https://index.docker.io/v1/".
+func registryHost(registry string) string {
+	if u, err := url.Parse(registry); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return strings.TrimSuffix(registry, "/")
+}
+
+// markRegistryCredentialsAvailable sets the RegistryCredentialsAvailable condition of config to true, along with its
+// deprecated v1beta1 counterpart.
+func markRegistryCredentialsAvailable(config *etcdbootstrapv1.EtcdadmConfig) {
+	conditions.Set(config, metav1.Condition{
+		Type:   etcdbootstrapv1.RegistryCredentialsAvailableCondition,
+		Status: metav1.ConditionTrue,
+		Reason: etcdbootstrapv1.RegistryCredentialsAvailableReason,
+	})
+	v1beta1conditions.MarkTrue(config, etcdbootstrapv1.RegistryCredentialsAvailableV1Beta1Condition)
+}
+
+// markRegistryCredentialsNotFound reports that the bootstrap data of config is rendered without registry credentials,
+// since it references none and the "registry-credentials" Secret cannot be read.
+func markRegistryCredentialsNotFound(config *etcdbootstrapv1.EtcdadmConfig, err error) {
+	message := "proceeding without registry credentials: " + err.Error()
+	conditions.Set(config, metav1.Condition{
+		Type:    etcdbootstrapv1.RegistryCredentialsAvailableCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  etcdbootstrapv1.RegistryCredentialsNotFoundReason,
+		Message: message,
+	})
+	v1beta1conditions.MarkFalse(config, etcdbootstrapv1.RegistryCredentialsAvailableV1Beta1Condition, etcdbootstrapv1.RegistryCredentialsNotFoundReason, clusterv1.ConditionSeverityWarning, "%s", message)
+}
+
+// markRegistryCredentialsUnavailable reports that the bootstrap data of config waits for its registry credentials.
+func markRegistryCredentialsUnavailable(config *etcdbootstrapv1.EtcdadmConfig, err error) {
+	for _, conditionType := range []string{etcdbootstrapv1.DataSecretAvailableCondition, etcdbootstrapv1.RegistryCredentialsAvailableCondition} {
+		conditions.Set(config, metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  etcdbootstrapv1.RegistryCredentialsUnavailableReason,
+			Message: err.Error(),
+		})
+	}
+	v1beta1conditions.MarkFalse(config, etcdbootstrapv1.DataSecretAvailableV1Beta1Condition, etcdbootstrapv1.RegistryCredentialsUnavailableReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+	v1beta1conditions.MarkFalse(config, etcdbootstrapv1.RegistryCredentialsAvailableV1Beta1Condition, etcdbootstrapv1.RegistryCredentialsUnavailableReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+}
@@ -0,0 +1,162 @@
+package etcd
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math"
+	"math/big"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+	"sigs.k8s.io/cluster-api/util/certs"
+)
+
+const (
+	clientCommonName = "etcdadm-bootstrap-provider"
+	dialTimeout      = 10 * time.Second
+	// clientCertDuration bounds the lifetime of the client certificates, they are only used for a single operation.
+	clientCertDuration = time.Hour
+	clockSkew          = 5 * time.Minute
+)
+
+// ClientTLSConfig returns a TLS config that authenticates against etcd with a short-lived client
+// certificate signed by the etcd CA of the cluster.
+func ClientTLSConfig(caCertPEM, caKeyPEM []byte) (*tls.Config, error) {
+	caCert, caKey, err := decodeCA(caCertPEM, caKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	cert, key, err := newClientCertificate(caCert, caKey, clientCommonName, clientCertDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	return &tls.Config{
+		RootCAs: pool,
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{cert.Raw},
+			PrivateKey:  key,
+			Leaf:        cert,
+		}},
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// NewClientCertificate returns a PEM encoded etcd client certificate and key signed by the etcd CA.
+func NewClientCertificate(caCertPEM, caKeyPEM []byte, commonName string, validity time.Duration) ([]byte, []byte, error) {
+	caCert, caKey, err := decodeCA(caCertPEM, caKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, key, err := newClientCertificate(caCert, caKey, commonName, validity)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certs.EncodeCertPEM(cert), certs.EncodePrivateKeyPEM(key), nil
+}
+
+func decodeCA(caCertPEM, caKeyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	caCert, err := certs.DecodeCertPEM(caCertPEM)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode etcd CA certificate")
+	}
+	if caCert == nil {
+		return nil, nil, errors.New("etcd CA certificate is empty")
+	}
+	caKey, err := certs.DecodePrivateKeyPEM(caKeyPEM)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode etcd CA key")
+	}
+	if caKey == nil {
+		return nil, nil, errors.New("etcd CA key is empty")
+	}
+	return caCert, caKey, nil
+}
+
+func newClientCertificate(caCert *x509.Certificate, caKey crypto.Signer, commonName string, validity time.Duration) (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, err := certs.NewPrivateKey()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate etcd client key")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate etcd client certificate serial number")
+	}
+	now := time.Now()
+	tmpl := x509.Certificate{
+		Subject:      pkix.Name{CommonName: commonName},
+		SerialNumber: serial,
+		NotBefore:    now.Add(-clockSkew).UTC(),
+		NotAfter:     now.Add(validity).UTC(),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign etcd client certificate")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse etcd client certificate")
+	}
+	return cert, key, nil
+}
+
+// RemoveMember removes the etcd member called name or advertising a peer URL on one of hosts through
+// the given endpoints. It returns the IDs of the removed members, none if the member was already gone.
+func RemoveMember(ctx context.Context, endpoints []string, tlsConfig *tls.Config, name string, hosts []string) ([]uint64, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		TLS:         tlsConfig,
+		DialTimeout: dialTimeout,
+		Logger:      zap.NewNop(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to etcd endpoints %v", endpoints)
+	}
+	defer client.Close()
+
+	members, err := client.MemberList(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd members")
+	}
+
+	var removed []uint64
+	for _, member := range members.Members {
+		if !isMember(member.Name, member.PeerURLs, name, hosts) {
+			continue
+		}
+		if _, err := client.MemberRemove(ctx, member.ID); err != nil {
+			return removed, errors.Wrapf(err, "failed to remove etcd member %x", member.ID)
+		}
+		removed = append(removed, member.ID)
+	}
+	return removed, nil
+}
+
+func isMember(memberName string, peerURLs []string, name string, hosts []string) bool {
+	if name != "" && memberName == name {
+		return true
+	}
+	for _, peerURL := range peerURLs {
+		u, err := url.Parse(peerURL)
+		if err != nil {
+			continue
+		}
+		if slices.Contains(hosts, u.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package etcd
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name        string
+		memberName  string
+		hosts       []string
+		wantRemoved int
+		wantMembers int
+	}{
+		{
+			name:        "removes the member advertising a peer url on the machine address",
+			hosts:       []string{"10.0.0.1", "10.0.0.2"},
+			wantRemoved: 1,
+			wantMembers: 1,
+		},
+		{
+			name:        "member is already gone",
+			memberName:  "machine-3",
+			hosts:       []string{"10.0.0.3"},
+			wantRemoved: 0,
+			wantMembers: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			ca := newEtcdCA(g)
+			endpoint := startEtcd(t, g, ca)
+
+			tlsConfig, err := ClientTLSConfig(ca.KeyPair.Cert, ca.KeyPair.Key)
+			g.Expect(err).NotTo(HaveOccurred())
+			client, err := clientv3.New(clientv3.Config{Endpoints: []string{endpoint}, TLS: tlsConfig, DialTimeout: dialTimeout})
+			g.Expect(err).NotTo(HaveOccurred())
+			defer client.Close()
+
+			// a learner that never starts does not count towards the quorum of the single member cluster
+			_, err = client.MemberAddAsLearner(ctx, []string{"https://10.0.0.2:2380"})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			removed, err := RemoveMember(ctx, []string{endpoint}, tlsConfig, tt.memberName, tt.hosts)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(removed).To(HaveLen(tt.wantRemoved))
+
+			members, err := client.MemberList(ctx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(members.Members).To(HaveLen(tt.wantMembers))
+		})
+	}
+}
+
+func TestRemoveMemberUntrustedCA(t *testing.T) {
+	g := NewWithT(t)
+
+	endpoint := startEtcd(t, g, newEtcdCA(g))
+	other := newEtcdCA(g)
+	tlsConfig, err := ClientTLSConfig(other.KeyPair.Cert, other.KeyPair.Key)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = RemoveMember(ctx, []string{endpoint}, tlsConfig, "", []string{"10.0.0.2"})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRemoveMemberUnreachable(t *testing.T) {
+	g := NewWithT(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	endpoint := "https://" + listener.Addr().String()
+	g.Expect(listener.Close()).To(Succeed())
+	ca := newEtcdCA(g)
+	tlsConfig, err := ClientTLSConfig(ca.KeyPair.Cert, ca.KeyPair.Key)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = RemoveMember(ctx, []string{endpoint}, tlsConfig, "", []string{"10.0.0.2"})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ctx.Err()).To(MatchError(context.DeadlineExceeded))
+}
+
+func TestClientTLSConfigShortLived(t *testing.T) {
+	g := NewWithT(t)
+
+	ca := newEtcdCA(g)
+	tlsConfig, err := ClientTLSConfig(ca.KeyPair.Cert, ca.KeyPair.Key)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tlsConfig.Certificates).To(HaveLen(1))
+	leaf := tlsConfig.Certificates[0].Leaf
+	g.Expect(leaf.NotAfter).To(BeTemporally("<=", time.Now().Add(clientCertDuration)))
+	g.Expect(leaf.ExtKeyUsage).To(ConsistOf(x509.ExtKeyUsageClientAuth))
+}
+
+func TestClientTLSConfigInvalidCA(t *testing.T) {
+	g := NewWithT(t)
+
+	ca := newEtcdCA(g)
+	_, err := ClientTLSConfig(nil, ca.KeyPair.Key)
+	g.Expect(err).To(HaveOccurred())
+	_, err = ClientTLSConfig(ca.KeyPair.Cert, []byte("not a key"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestNewClientCertificate(t *testing.T) {
+	g := NewWithT(t)
+
+	ca := newEtcdCA(g)
+	certPEM, keyPEM, err := NewClientCertificate(ca.KeyPair.Cert, ca.KeyPair.Key, "kube-apiserver-etcd-client", 24*time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cert, err := certs.DecodeCertPEM(certPEM)
+	g.Expect(err).NotTo(HaveOccurred())
+	caCert, err := certs.DecodeCertPEM(ca.KeyPair.Cert)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cert.CheckSignatureFrom(caCert)).To(Succeed())
+	g.Expect(cert.Subject.CommonName).To(Equal("kube-apiserver-etcd-client"))
+	g.Expect(cert.ExtKeyUsage).To(ConsistOf(x509.ExtKeyUsageClientAuth))
+	g.Expect(cert.NotAfter).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+
+	key, err := certs.DecodePrivateKeyPEM(keyPEM)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(key.Public()).To(Equal(cert.PublicKey))
+}
+
+func newEtcdCA(g *WithT) *secret.Certificate {
+	ca := &secret.Certificate{Purpose: secret.ManagedExternalEtcdCA}
+	g.Expect(ca.Generate()).To(Succeed())
+	return ca
+}
+
+// startEtcd starts an embedded single member etcd server requiring client certificates signed by ca
+// and returns its client endpoint.
+func startEtcd(t *testing.T, g *WithT, ca *secret.Certificate) string {
+	dir := t.TempDir()
+
+	caCert, err := certs.DecodeCertPEM(ca.KeyPair.Cert)
+	g.Expect(err).NotTo(HaveOccurred())
+	caKey, err := certs.DecodePrivateKeyPEM(ca.KeyPair.Key)
+	g.Expect(err).NotTo(HaveOccurred())
+	serverKey, err := certs.NewPrivateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	serverConfig := certs.Config{
+		CommonName: "etcd",
+		AltNames:   certs.AltNames{IPs: []net.IP{net.ParseIP("127.0.0.1")}},
+		Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	serverCert, err := serverConfig.NewSignedCert(serverKey, caCert, caKey)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	files := map[string][]byte{
+		"ca.crt":     ca.KeyPair.Cert,
+		"server.crt": certs.EncodeCertPEM(serverCert),
+		"server.key": certs.EncodePrivateKeyPEM(serverKey),
+	}
+	for name, content := range files {
+		g.Expect(os.WriteFile(filepath.Join(dir, name), content, 0o600)).To(Succeed())
+	}
+
+	clientURL := url.URL{Scheme: "https", Host: fmt.Sprintf("127.0.0.1:%d", freePort(g))}
+	peerURL := url.URL{Scheme: "http", Host: fmt.Sprintf("127.0.0.1:%d", freePort(g))}
+
+	cfg := embed.NewConfig()
+	cfg.Name = "etcd-0"
+	cfg.Dir = filepath.Join(dir, "data")
+	cfg.LogLevel = "error"
+	cfg.ListenClientUrls = []url.URL{clientURL}
+	cfg.AdvertiseClientUrls = []url.URL{clientURL}
+	cfg.ListenPeerUrls = []url.URL{peerURL}
+	cfg.AdvertisePeerUrls = []url.URL{peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+	cfg.ClientTLSInfo.CertFile = filepath.Join(dir, "server.crt")
+	cfg.ClientTLSInfo.KeyFile = filepath.Join(dir, "server.key")
+	cfg.ClientTLSInfo.TrustedCAFile = filepath.Join(dir, "ca.crt")
+	cfg.ClientTLSInfo.ClientCertAuth = true
+
+	e, err := embed.StartEtcd(cfg)
+	g.Expect(err).NotTo(HaveOccurred())
+	t.Cleanup(e.Close)
+
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(30 * time.Second):
+		t.Fatal("embedded etcd server did not become ready")
+	}
+	return clientURL.String()
+}
+
+func freePort(g *WithT) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
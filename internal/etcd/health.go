@@ -0,0 +1,62 @@
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// healthCheckKey is the key etcdctl reads to check the health of an endpoint.
+const healthCheckKey = "health"
+
+// MemberHealth is the health of a single etcd member as seen through its own client endpoint.
+type MemberHealth struct {
+	ID       uint64
+	Leader   uint64
+	IsLeader bool
+	DBSize   int64
+	// Healthy is true when the member serves a linearizable read, which requires the cluster to have quorum,
+	// and reports no errors such as alarms.
+	Healthy bool
+	Errors  []string
+}
+
+// CheckMember queries the status of the member serving endpoint and checks whether it is healthy.
+func CheckMember(ctx context.Context, endpoint string, tlsConfig *tls.Config) (*MemberHealth, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{endpoint},
+		TLS:         tlsConfig,
+		DialTimeout: dialTimeout,
+		Logger:      zap.NewNop(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to etcd endpoint %s", endpoint)
+	}
+	defer client.Close()
+
+	status, err := client.Status(ctx, endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get status of etcd endpoint %s", endpoint)
+	}
+	health := &MemberHealth{
+		ID:       status.Header.MemberId,
+		Leader:   status.Leader,
+		IsLeader: status.Header.MemberId == status.Leader,
+		DBSize:   status.DbSize,
+		Errors:   status.Errors,
+	}
+
+	// the read reaches consensus even if permission to the key is denied
+	_, err = client.Get(ctx, healthCheckKey)
+	switch {
+	case err == nil || errors.Is(err, rpctypes.ErrPermissionDenied):
+		health.Healthy = len(health.Errors) == 0
+	default:
+		health.Errors = append(health.Errors, err.Error())
+	}
+	return health, nil
+}
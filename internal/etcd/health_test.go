@@ -0,0 +1,26 @@
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCheckMember(t *testing.T) {
+	g := NewWithT(t)
+
+	ca := newEtcdCA(g)
+	endpoint := startEtcd(t, g, ca)
+	tlsConfig, err := ClientTLSConfig(ca.KeyPair.Cert, ca.KeyPair.Key)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	health, err := CheckMember(context.Background(), endpoint, tlsConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(health.Healthy).To(BeTrue())
+	g.Expect(health.IsLeader).To(BeTrue())
+	g.Expect(health.ID).NotTo(BeZero())
+	g.Expect(health.Leader).To(Equal(health.ID))
+	g.Expect(health.DBSize).To(BeNumerically(">", 0))
+	g.Expect(health.Errors).To(BeEmpty())
+}
@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"os"
+	"time"
 
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,6 +33,7 @@ import (
 
 	bootstrapv1alpha3 "github.com/aws/etcdadm-bootstrap-provider/api/v1alpha3"
 	bootstrapv1beta1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	bootstrapv1beta2 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/aws/etcdadm-bootstrap-provider/controllers"
 	// +kubebuilder:scaffold:imports
 )
@@ -42,6 +44,9 @@ var (
 	watchNamespace       string
 	managerOptions       capiflags.ManagerOptions
 	enableLeaderElection bool
+	healthCheckInterval  time.Duration
+
+	maxSnapshotBootstrapDataSize int
 )
 
 func init() {
@@ -50,6 +55,7 @@ func init() {
 	_ = clusterv1.AddToScheme(scheme)
 	_ = bootstrapv1alpha3.AddToScheme(scheme)
 	_ = bootstrapv1beta1.AddToScheme(scheme)
+	_ = bootstrapv1beta2.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -65,6 +71,11 @@ func main() {
 	pflag.StringVar(&watchNamespace, "namespace", "",
 		"Namespace that the controller watches to reconcile etcdadmConfig objects. If unspecified, the controller watches forobjects across all namespaces.")
 
+	pflag.DurationVar(&healthCheckInterval, "etcd-health-check-interval", controllers.DefaultHealthCheckInterval,
+		"Interval between two health checks of the etcd member of each ready etcdadmConfig. Set to 0 to disable health checks.")
+	pflag.IntVar(&maxSnapshotBootstrapDataSize, "max-snapshot-bootstrap-data-size", 0,
+		"Maximum size in bytes of bootstrap data embedding a snapshot to restore from, read from a Secret or ConfigMap, usually the user data limit of the infrastructure provider. The size is not checked when it is 0.")
+
 	pflag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -99,15 +110,22 @@ func main() {
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("controllers").WithName("EtcdadmConfig"),
 		Scheme: mgr.GetScheme(),
+
+		HealthCheckInterval:          healthCheckInterval,
+		MaxSnapshotBootstrapDataSize: maxSnapshotBootstrapDataSize,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "EtcdadmConfig")
 		os.Exit(1)
 	}
 
-	if err = (&bootstrapv1beta1.EtcdadmConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err = (&bootstrapv1beta2.EtcdadmConfig{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "EtcdadmConfig")
 		os.Exit(1)
 	}
+	if err = (&bootstrapv1beta2.EtcdadmConfigTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "EtcdadmConfigTemplate")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
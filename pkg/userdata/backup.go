@@ -0,0 +1,67 @@
+package userdata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+)
+
+const (
+	DefaultBackupSchedule  = "hourly"
+	DefaultBackupLocalPath = "/var/lib/etcd-backup"
+	DefaultBackupS3Region  = "us-east-1"
+)
+
+// BackupEnvironment returns the environment file consumed by the etcd backup job.
+// certsDir is the directory holding the etcd CA and the etcdctl client certificate on the node, and rootDir is where
+// the root filesystem of the node is mounted for the job, so that snapshots are written to the node rather than to the
+// filesystem of a container.
+func BackupEnvironment(backup *etcdbootstrapv1.BackupConfiguration, etcdctl, certsDir, rootDir string, credentials BackupCredentials) string {
+	env := map[string]string{
+		"ETCDCTL":          etcdctl,
+		"ETCD_CACERT":      certsDir + "/ca.crt",
+		"ETCD_CERT":        certsDir + "/etcdctl-etcd-client.crt",
+		"ETCD_KEY":         certsDir + "/etcdctl-etcd-client.key",
+		"BACKUP_SCHEDULE":  BackupSchedule(backup),
+		"BACKUP_DIR":       rootDir + DefaultBackupLocalPath,
+		"BACKUP_RETENTION": fmt.Sprintf("%d", backup.Retention),
+		// snapshots only staged locally for an upload are removed once uploaded
+		"BACKUP_KEEP_LOCAL": fmt.Sprintf("%t", backup.LocalPath != "" || backup.S3 == nil),
+	}
+	if backup.LocalPath != "" {
+		env["BACKUP_DIR"] = rootDir + backup.LocalPath
+	}
+	if backup.S3 != nil {
+		env["S3_ENDPOINT"] = strings.TrimSuffix(backup.S3.Endpoint, "/")
+		env["S3_BUCKET"] = backup.S3.Bucket
+		env["S3_PREFIX"] = backup.S3.Prefix
+		env["S3_REGION"] = DefaultBackupS3Region
+		if backup.S3.Region != "" {
+			env["S3_REGION"] = backup.S3.Region
+		}
+		env["AWS_ACCESS_KEY_ID"] = credentials.AccessKeyID
+		env["AWS_SECRET_ACCESS_KEY"] = credentials.SecretAccessKey
+	}
+
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%q", key, env[key]))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// BackupSchedule returns the calendar expression backups run on.
+func BackupSchedule(backup *etcdbootstrapv1.BackupConfiguration) string {
+	if backup.Schedule == "" {
+		return DefaultBackupSchedule
+	}
+	return backup.Schedule
+}
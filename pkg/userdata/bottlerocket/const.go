@@ -16,14 +16,3 @@ write_files:{{ range . }}
 {{- end -}}
 `
 )
-
-const (
-	usersTemplate = `{{- if . }}
-{
-	"ssh": {
-		"authorized-keys": [{{.}}]
-	}
-}
-{{- end -}}
-`
-)
@@ -3,7 +3,7 @@ package bottlerocket
 import (
 	"fmt"
 
-	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
 	"github.com/go-logr/logr"
 )
@@ -22,9 +22,8 @@ func NewInitEtcdPlane(input *userdata.EtcdPlaneInput, config etcdbootstrapv1.Etc
 	input.WriteFiles = userdata.ConvertCertificateFiles(input.AsFiles())
 	prepare(&input.BaseUserData)
 	input.EtcdadmArgs = buildEtcdadmArgs(config)
-	logIgnoredFields(&input.BaseUserData, log)
 	input.EtcdadmInitCommand = fmt.Sprintf("EtcdadmInit %s %s %s", input.ImageRepository, input.Version, input.CipherSuites)
-	userData, err := generateUserData("InitEtcdplane", etcdInitCloudInit, input, &input.BaseUserData, config, log)
+	userData, err := generateUserData("InitEtcdplane", etcdInitCloudInit, input, &input.BaseUserData, nil, config, log)
 	if err != nil {
 		return nil, err
 	}
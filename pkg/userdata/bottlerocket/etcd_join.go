@@ -3,7 +3,7 @@ package bottlerocket
 import (
 	"fmt"
 
-	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -25,10 +25,16 @@ func NewJoinEtcdPlane(input *userdata.EtcdPlaneJoinInput, config etcdbootstrapv1
 	input.WriteFiles = userdata.ConvertCertificateFiles(input.Certificates.AsFiles())
 	prepare(&input.BaseUserData)
 	input.EtcdadmArgs = buildEtcdadmArgs(config)
-	logIgnoredFields(&input.BaseUserData, log)
 	input.ControlPlane = true
 	input.EtcdadmJoinCommand = fmt.Sprintf("EtcdadmJoin %s %s %s %s", input.ImageRepository, input.Version, input.CipherSuites, input.JoinAddress)
-	userData, err := generateUserData("JoinControlplane", etcdPlaneJoinCloudInit, input, &input.BaseUserData, config, log)
+	var learner *learnerUserData
+	if config.JoinAsLearner {
+		var err error
+		if learner, err = newLearnerUserData(config.BottlerocketConfig.LearnerImage, input.JoinAddress, input.Certificates); err != nil {
+			return nil, err
+		}
+	}
+	userData, err := generateUserData("JoinControlplane", etcdPlaneJoinCloudInit, input, &input.BaseUserData, learner, config, log)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to generate user data for machine joining control plane")
 	}
@@ -0,0 +1,64 @@
+package bottlerocket
+
+import (
+	"encoding/base64"
+
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	// learnerContainer is the name of the bootstrap container adding the member as a learner, and of the host
+	// container promoting it.
+	learnerContainer = "etcd-learner"
+
+	// learnerAddScript adds the member as a learner. It runs in an essential bootstrap container, which Bottlerocket
+	// runs to completion before it starts the host containers, so the member is a learner by the time the
+	// kubeadm-bootstrap container joins it.
+	learnerAddScript = `
+add_learner
+`
+
+	// learnerPromoteScript promotes the learner once it has caught up with the leader. It runs in a host container,
+	// alongside the kubeadm-bootstrap container, and keeps trying until the member is a voting member. Bottlerocket
+	// restarts host containers that exit, so it sleeps once it is done.
+	learnerPromoteScript = `
+until promote; do
+  sleep 5
+done
+exec sleep infinity
+`
+)
+
+// learnerUserData holds the user data of the learner containers.
+type learnerUserData struct {
+	Image   string
+	Add     string
+	Promote string
+}
+
+// newLearnerUserData returns the user data of the containers adding the member joining through joinAddress as a
+// learner and promoting it. The containers run before the etcd certificates are written to the host, so the scripts
+// carry the etcd CA themselves.
+func newLearnerUserData(image, joinAddress string, certificates secret.Certificates) (*learnerUserData, error) {
+	ca := certificates.GetByPurpose(secret.ManagedExternalEtcdCA)
+	if ca == nil || ca.KeyPair == nil {
+		return nil, errors.New("the etcd CA is required to join members as learners")
+	}
+
+	header := `#!/bin/bash
+set -euo pipefail
+
+endpoint=` + userdata.ShellQuote(joinAddress) + `
+pki="$(mktemp -d)"
+printf '%s' ` + userdata.ShellQuote(string(ca.KeyPair.Cert)) + ` > "${pki}/ca.crt"
+printf '%s' ` + userdata.ShellQuote(string(ca.KeyPair.Key)) + ` > "${pki}/ca.key"
+` + userdata.LearnerFunctions
+
+	return &learnerUserData{
+		Image:   image,
+		Add:     base64.StdEncoding.EncodeToString([]byte(header + learnerAddScript)),
+		Promote: base64.StdEncoding.EncodeToString([]byte(header + learnerPromoteScript)),
+	}, nil
+}
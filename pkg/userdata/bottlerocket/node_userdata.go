@@ -3,369 +3,258 @@ package bottlerocket
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
-	"text/template"
+	"maps"
+	"slices"
 
-	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	"github.com/BurntSushi/toml"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
 )
 
-const (
-	hostContainersTemplate = `{{ define "hostContainersSettings" -}}
-{{- range .HostContainers }}
-[settings.host-containers.{{ .Name }}]
-enabled = true
-superpowered = {{ .Superpowered }}
-{{- if .Image }}
-source = "{{ .Image }}"
-{{- end }}
-{{- if .UserData }}
-user-data = "{{ .UserData }}"
-{{- end }}
-{{- end }}
-{{- end }}
-`
-
-	bootstrapContainersTemplate = `{{ define "bootstrapContainersSettings" -}}
-{{- range .BootstrapContainers }}
-[settings.bootstrap-containers.{{ .Name }}]
-essential = {{ .Essential }}
-mode = "{{ .Mode }}"
-{{- if .Image }}
-source = "{{ .Image }}"
-{{- end }}
-{{- if .UserData }}
-user-data = "{{ .UserData }}"
-{{- end }}
-{{- end }}
-{{- end }}
-`
-
-	kubernetesInitTemplate = `{{ define "kubernetesInitSettings" -}}
-[settings.kubernetes]
-cluster-domain = "cluster.local"
-standalone-mode = true
-authentication-mode = "tls"
-server-tls-bootstrap = false
-pod-infra-container-image = "{{.PauseContainerSource}}"
-{{- end -}}
-`
-	networkInitTemplate = `{{ define "networkInitSettings" -}}
-[settings.network]
-hostname = "{{.Hostname}}"
-{{- if (ne .HTTPSProxyEndpoint "")}}
-https-proxy = "{{.HTTPSProxyEndpoint}}"
-no-proxy = [{{stringsJoin .NoProxyEndpoints "," }}]
-{{- end -}}
-{{- end -}}
-`
-	registryMirrorTemplate = `{{ define "registryMirrorSettings" -}}
-[settings.container-registry.mirrors]
-"public.ecr.aws" = ["https://{{.RegistryMirrorEndpoint}}"]
-{{- end -}}
-`
-	registryMirrorCACertTemplate = `{{ define "registryMirrorCACertSettings" -}}
-[settings.pki.registry-mirror-ca]
-data = "{{.RegistryMirrorCACert}}"
-trusted=true
-{{- end -}}
-`
-	registryMirrorCredentialsTemplate = `{{ define "registryMirrorCredentialsSettings" -}}
-[[settings.container-registry.credentials]]
-registry = "public.ecr.aws"
-username = "{{.RegistryMirrorUsername}}"
-password = "{{.RegistryMirrorPassword}}"
-[[settings.container-registry.credentials]]
-registry = "{{.RegistryMirrorEndpoint}}"
-username = "{{.RegistryMirrorUsername}}"
-password = "{{.RegistryMirrorPassword}}"
-{{- end -}}
-`
-	ntpTemplate = `{{ define "ntpSettings" -}}
-[settings.ntp]
-time-servers = [{{stringsJoin .NTPServers ", " }}]
-{{- end -}}
-`
-	sysctlSettingsTemplate = `{{ define "sysctlSettingsTemplate" -}}
-[settings.kernel.sysctl]
-{{.SysctlSettings}}
-{{- end -}}
-`
-	bootSettingsTemplate = `{{ define "bootSettings" -}}
-[settings.boot]
-reboot-to-reconcile = true
-
-[settings.boot.kernel-parameters]
-{{.BootKernel}}
-{{- end -}}
-`
-	certsTemplate = `{{ define "certsSettings" -}}
-[settings.pki.{{.Name}}]
-data = "{{.Data}}"
-trusted = true
-{{- end -}}
-`
-	certBundlesSliceTemplate = `{{ define "certBundlesSlice" -}}
-{{- range $cBundle := .CertBundles }}
-{{template "certsSettings" $cBundle }}
-{{- end -}}
-{{- end -}}
-`
-	bottlerocketNodeInitSettingsTemplate = `{{template "hostContainersSettings" .}}
-
-{{template "kubernetesInitSettings" .}}
-
-{{template "networkInitSettings" .}}
-
-{{- if .BootstrapContainers }}
-{{template "bootstrapContainersSettings" .}}
-{{- end -}}
-
-{{- if (ne .RegistryMirrorEndpoint "")}}
-{{template "registryMirrorSettings" .}}
-{{- end -}}
-
-{{- if (ne .RegistryMirrorCACert "")}}
-{{template "registryMirrorCACertSettings" .}}
-{{- end -}}
-
-{{- if and (ne .RegistryMirrorUsername "") (ne .RegistryMirrorPassword "")}}
-{{template "registryMirrorCredentialsSettings" .}}
-{{- end -}}
-
-{{- if .NTPServers}}
-{{template "ntpSettings" .}}
-{{- end -}}
-
-{{- if (ne .SysctlSettings "")}}
-{{template "sysctlSettingsTemplate" .}}
-{{- end -}}
-
-{{- if .BootKernel}}
-{{template "bootSettings" .}}
-{{- end -}}
-
-{{- if .CertBundles}}
-{{template "certBundlesSlice" .}}
-{{- end -}}
-`
-)
-
-type bottlerocketSettingsInput struct {
-	PauseContainerSource   string
-	HTTPSProxyEndpoint     string
-	NoProxyEndpoints       []string
-	RegistryMirrorEndpoint string
-	RegistryMirrorCACert   string
-	RegistryMirrorUsername string
-	RegistryMirrorPassword string
-	Hostname               string
-	HostContainers         []etcdbootstrapv1.BottlerocketHostContainer
-	BootstrapContainers    []etcdbootstrapv1.BottlerocketBootstrapContainer
-	NTPServers             []string
-	SysctlSettings         string
-	BootKernel             string
-	CertBundles            []bootstrapv1.CertBundle
-}
-
 // generateBottlerocketNodeUserData returns the userdata for the host bottlerocket in toml format
-func generateBottlerocketNodeUserData(kubeadmBootstrapContainerUserData []byte, users []bootstrapv1.User, registryMirrorCredentials userdata.RegistryMirrorCredentials, hostname string, config etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error) {
-	// base64 encode the kubeadm bootstrapContainer's user data
-	b64KubeadmBootstrapContainerUserData := base64.StdEncoding.EncodeToString(kubeadmBootstrapContainerUserData)
-
-	// Parse out all the ssh authorized keys
-	sshAuthorizedKeys := getAllAuthorizedKeys(users)
-
+func generateBottlerocketNodeUserData(kubeadmBootstrapContainerUserData []byte, users []bootstrapv1.User, registryMirrorCredentials userdata.RegistryMirrorCredentials, backupCredentials userdata.BackupCredentials, hostname string, learner *learnerUserData, config etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error) {
 	// generate the userdata for the admin container
-	adminContainerUserData, err := generateAdminContainerUserData("InitAdminContainer", usersTemplate, sshAuthorizedKeys)
+	adminContainerUserData, err := generateAdminContainerUserData(users)
 	if err != nil {
 		return nil, err
 	}
-	b64AdminContainerUserData := base64.StdEncoding.EncodeToString(adminContainerUserData)
 
-	hostContainers := []etcdbootstrapv1.BottlerocketHostContainer{
-		{
-			Name:         "admin",
+	hostContainers := map[string]hostContainer{
+		"admin": {
+			Enabled:      true,
 			Superpowered: true,
-			Image:        config.BottlerocketConfig.AdminImage,
-			UserData:     b64AdminContainerUserData,
+			Source:       config.BottlerocketConfig.AdminImage,
+			UserData:     base64.StdEncoding.EncodeToString(adminContainerUserData),
 		},
-		{
-			Name:         "kubeadm-bootstrap",
+		"kubeadm-bootstrap": {
+			Enabled:      true,
 			Superpowered: true,
-			Image:        config.BottlerocketConfig.BootstrapImage,
-			UserData:     b64KubeadmBootstrapContainerUserData,
+			Source:       config.BottlerocketConfig.BootstrapImage,
+			// base64 encode the kubeadm bootstrapContainer's user data
+			UserData: base64.StdEncoding.EncodeToString(kubeadmBootstrapContainerUserData),
 		},
 	}
 
 	if config.BottlerocketConfig.ControlImage != "" {
-		hostContainers = append(hostContainers, etcdbootstrapv1.BottlerocketHostContainer{
-			Name:         "control",
+		hostContainers["control"] = hostContainer{
+			Enabled:      true,
 			Superpowered: false,
-			Image:        config.BottlerocketConfig.ControlImage,
-		})
-	}
-
-	bottlerocketInput := &bottlerocketSettingsInput{
-		PauseContainerSource: config.BottlerocketConfig.PauseImage,
-		HostContainers:       hostContainers,
-		BootstrapContainers:  config.BottlerocketConfig.CustomBootstrapContainers,
-		Hostname:             hostname,
-	}
-
-	if config.Proxy != nil {
-		bottlerocketInput.HTTPSProxyEndpoint = config.Proxy.HTTPSProxy
-		for _, noProxy := range config.Proxy.NoProxy {
-			bottlerocketInput.NoProxyEndpoints = append(bottlerocketInput.NoProxyEndpoints, strconv.Quote(noProxy))
+			Source:       config.BottlerocketConfig.ControlImage,
 		}
 	}
 
-	if config.RegistryMirror != nil {
-		bottlerocketInput.RegistryMirrorEndpoint = config.RegistryMirror.Endpoint
-		if config.RegistryMirror.CACert != "" {
-			bottlerocketInput.RegistryMirrorCACert = base64.StdEncoding.EncodeToString([]byte(config.RegistryMirror.CACert))
+	if config.Backup != nil {
+		backupEnvironment := userdata.BackupEnvironment(config.Backup, "etcdctl", backupCertsPath, hostRootPath, backupCredentials)
+		hostContainers["etcd-backup"] = hostContainer{
+			Enabled:      true,
+			Superpowered: true,
+			Source:       config.Backup.Image,
+			UserData:     base64.StdEncoding.EncodeToString([]byte(backupEnvironment)),
 		}
-		bottlerocketInput.RegistryMirrorUsername = registryMirrorCredentials.Username
-		bottlerocketInput.RegistryMirrorPassword = registryMirrorCredentials.Password
 	}
 
-	if config.NTP != nil && config.NTP.Enabled != nil && *config.NTP.Enabled {
-		for _, ntpServer := range config.NTP.Servers {
-			bottlerocketInput.NTPServers = append(bottlerocketInput.NTPServers, strconv.Quote(ntpServer))
-		}
+	nodeSettings := settings{
+		HostContainers: hostContainers,
+		Kubernetes: kubernetesSettings{
+			ClusterDomain:          "cluster.local",
+			StandaloneMode:         true,
+			AuthenticationMode:     "tls",
+			ServerTLSBootstrap:     false,
+			PodInfraContainerImage: config.BottlerocketConfig.PauseImage,
+		},
+		Network: networkSettings{
+			Hostname: hostname,
+		},
 	}
 
-	if config.CertBundles != nil {
-		for _, cert := range config.CertBundles {
-			cert.Data = base64.StdEncoding.EncodeToString([]byte(cert.Data))
-			bottlerocketInput.CertBundles = append(bottlerocketInput.CertBundles, cert)
+	if len(config.BottlerocketConfig.CustomBootstrapContainers) > 0 {
+		nodeSettings.BootstrapContainers = map[string]bootstrapContainer{}
+		for _, container := range config.BottlerocketConfig.CustomBootstrapContainers {
+			nodeSettings.BootstrapContainers[container.Name] = bootstrapContainer{
+				Essential: container.Essential,
+				Mode:      container.Mode,
+				Source:    container.Image,
+				UserData:  container.UserData,
+			}
 		}
 	}
 
-	if config.BottlerocketConfig != nil {
-		if config.BottlerocketConfig.Kernel != nil {
-			bottlerocketInput.SysctlSettings = parseSysctlSettings(config.BottlerocketConfig.Kernel.SysctlSettings)
+	if learner != nil {
+		if nodeSettings.BootstrapContainers == nil {
+			nodeSettings.BootstrapContainers = map[string]bootstrapContainer{}
 		}
-		if config.BottlerocketConfig.Boot != nil {
-			bottlerocketInput.BootKernel = parseBootSettings(config.BottlerocketConfig.Boot.BootKernelParameters)
+		nodeSettings.BootstrapContainers[learnerContainer] = bootstrapContainer{
+			Essential: true,
+			Mode:      "once",
+			Source:    learner.Image,
+			UserData:  learner.Add,
+		}
+		hostContainers[learnerContainer] = hostContainer{
+			Enabled:      true,
+			Superpowered: true,
+			Source:       learner.Image,
+			UserData:     learner.Promote,
 		}
 	}
 
-	bottlerocketNodeUserData, err := generateNodeUserData("InitBottlerocketNode", bottlerocketNodeInitSettingsTemplate, bottlerocketInput)
-	if err != nil {
-		return nil, err
-	}
-	log.Info("Generated bottlerocket bootstrap userdata", "bootstrapContainerImage", config.BottlerocketConfig.BootstrapImage)
-	return bottlerocketNodeUserData, nil
-}
-
-// parseKernelSettings parses through all the the settings and returns a list of the settings.
-func parseSysctlSettings(sysctlSettings map[string]string) string {
-	sysctlSettingsToml := ""
-	for key, value := range sysctlSettings {
-		sysctlSettingsToml += fmt.Sprintf("\"%s\" = \"%s\"\n", key, value)
+	if config.Proxy != nil && (config.Proxy.HTTPSProxy != "" || config.Proxy.HTTPProxy != "") {
+		// Bottlerocket has no http-proxy setting, so the HTTP proxy is only used when there is no HTTPS proxy
+		nodeSettings.Network.HTTPSProxy = config.Proxy.HTTPSProxy
+		if nodeSettings.Network.HTTPSProxy == "" {
+			nodeSettings.Network.HTTPSProxy = config.Proxy.HTTPProxy
+		}
+		nodeSettings.Network.NoProxy = config.Proxy.NoProxy
 	}
-	return sysctlSettingsToml
-}
 
-// parseBootSettings parses through all the boot settings and returns a list of the settings.
-func parseBootSettings(bootSettings map[string][]string) string {
-	bootSettingsToml := ""
-	for key, value := range bootSettings {
-		var values []string
-		if len(value) != 0 {
-			for _, val := range value {
-				quotedVal := "\"" + val + "\""
-				values = append(values, quotedVal)
+	if network := config.Network; network != nil {
+		for _, host := range network.Hosts {
+			nodeSettings.Network.Hosts = append(nodeSettings.Network.Hosts, []interface{}{host.IP, host.Hostnames})
+		}
+		if len(network.NameServers) > 0 || len(network.SearchDomains) > 0 {
+			nodeSettings.DNS = &dnsSettings{
+				NameServers: network.NameServers,
+				SearchList:  network.SearchDomains,
 			}
 		}
-		keyVal := strings.Join(values, ",")
-		bootSettingsToml += fmt.Sprintf("\"%v\" = [%v]\n", key, keyVal)
 	}
-	return bootSettingsToml
-}
 
-// getAllAuthorizedKeys parses through all the users and return list of all user's authorized ssh keys
-func getAllAuthorizedKeys(users []bootstrapv1.User) string {
-	var sshAuthorizedKeys []string
-	for _, user := range users {
-		if len(user.SSHAuthorizedKeys) != 0 {
-			for _, key := range user.SSHAuthorizedKeys {
-				quotedKey := "\"" + key + "\""
-				sshAuthorizedKeys = append(sshAuthorizedKeys, quotedKey)
+	if config.RegistryMirror != nil {
+		nodeSettings.ContainerRegistry = &containerRegistrySettings{}
+		var registries []string
+		for _, mirror := range userdata.RegistryMirrors(config.RegistryMirror) {
+			if nodeSettings.ContainerRegistry.Mirrors == nil {
+				nodeSettings.ContainerRegistry.Mirrors = map[string][]string{}
+			}
+			nodeSettings.ContainerRegistry.Mirrors[mirror.Upstream] = []string{userdata.RegistryMirrorURL(mirror)}
+			for _, registry := range []string{mirror.Upstream, mirror.Endpoint} {
+				if !slices.Contains(registries, registry) {
+					registries = append(registries, registry)
+				}
+			}
+		}
+		if config.RegistryMirror.CACert != "" {
+			addPKI(&nodeSettings, "registry-mirror-ca", config.RegistryMirror.CACert)
+		}
+		for i, mirror := range config.RegistryMirror.Mirrors {
+			if mirror.CACert != "" {
+				addPKI(&nodeSettings, fmt.Sprintf("registry-mirror-ca-%d", i), mirror.CACert)
+			}
+		}
+		var others []string
+		for registry := range registryMirrorCredentials.Registries {
+			if !slices.Contains(registries, registry) {
+				others = append(others, registry)
+			}
+		}
+		slices.Sort(others)
+		for _, registry := range append(registries, others...) {
+			if credentials, ok := registryMirrorCredentials.For(registry); ok {
+				nodeSettings.ContainerRegistry.Credentials = append(nodeSettings.ContainerRegistry.Credentials, containerRegistryCredential{
+					Registry: registry,
+					Username: credentials.Username,
+					Password: credentials.Password,
+				})
 			}
 		}
 	}
-	return strings.Join(sshAuthorizedKeys, ",")
-}
 
-func generateAdminContainerUserData(kind string, tpl string, data interface{}) ([]byte, error) {
-	tm := template.New(kind)
-	if _, err := tm.Parse(usersTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse users - %s template", kind)
-	}
-	t, err := tm.Parse(tpl)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse %s template", kind)
-	}
-	var out bytes.Buffer
-	if err := t.Execute(&out, data); err != nil {
-		return nil, errors.Wrapf(err, "failed to generate %s template", kind)
+	if registry := nodeSettings.ContainerRegistry; registry != nil && len(registry.Mirrors) == 0 && len(registry.Credentials) == 0 {
+		nodeSettings.ContainerRegistry = nil
 	}
-	return out.Bytes(), nil
-}
 
-func generateNodeUserData(kind string, tpl string, data interface{}) ([]byte, error) {
-	tm := template.New(kind).Funcs(template.FuncMap{"stringsJoin": strings.Join})
-	if _, err := tm.Parse(hostContainersTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse hostContainers %s template", kind)
+	if config.NTP != nil && config.NTP.Enabled != nil && *config.NTP.Enabled && len(config.NTP.Servers) > 0 {
+		nodeSettings.NTP = &ntpSettings{
+			TimeServers: config.NTP.Servers,
+		}
 	}
-	if _, err := tm.Parse(bootstrapContainersTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse bootstrapContainers %s template", kind)
+
+	for _, cert := range config.CertBundles {
+		addPKI(&nodeSettings, cert.Name, cert.Data)
 	}
-	if _, err := tm.Parse(kubernetesInitTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse kubernetes %s template", kind)
+
+	// the kernel settings of the spec apply to both formats, the bottlerocket ones override them
+	sysctl := map[string]string{}
+	kernelParameters := map[string][]string{}
+	if kernel := config.Kernel; kernel != nil {
+		maps.Copy(sysctl, kernel.SysctlSettings)
+		maps.Copy(kernelParameters, kernel.BootKernelParameters)
 	}
-	if _, err := tm.Parse(networkInitTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse networks %s template", kind)
+	if kernel := config.BottlerocketConfig.Kernel; kernel != nil {
+		maps.Copy(sysctl, kernel.SysctlSettings)
 	}
-	if _, err := tm.Parse(registryMirrorTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse registry mirror %s template", kind)
+	if boot := config.BottlerocketConfig.Boot; boot != nil {
+		maps.Copy(kernelParameters, boot.BootKernelParameters)
 	}
-	if _, err := tm.Parse(registryMirrorCACertTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse registry mirror ca cert %s template", kind)
+	if len(sysctl) > 0 {
+		nodeSettings.Kernel = &kernelSettings{
+			Sysctl: sysctl,
+		}
 	}
-	if _, err := tm.Parse(registryMirrorCredentialsTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse registry mirror credentials %s template", kind)
+	if len(kernelParameters) > 0 {
+		nodeSettings.Boot = &bootSettings{
+			RebootToReconcile: true,
+			KernelParameters:  kernelParameters,
+		}
 	}
-	if _, err := tm.Parse(ntpTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse NTP %s template", kind)
+
+	bottlerocketNodeUserData, err := encodeSettings(nodeSettings, config.BottlerocketConfig.Settings)
+	if err != nil {
+		return nil, err
 	}
-	if _, err := tm.Parse(sysctlSettingsTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse sysctl settings %s template", kind)
+	log.Info("Generated bottlerocket bootstrap userdata", "bootstrapContainerImage", config.BottlerocketConfig.BootstrapImage)
+	return bottlerocketNodeUserData, nil
+}
+
+// addPKI adds the PEM encoded certificates in data to the trusted certificates of the host.
+func addPKI(nodeSettings *settings, name, data string) {
+	if nodeSettings.PKI == nil {
+		nodeSettings.PKI = map[string]pkiSettings{}
 	}
-	if _, err := tm.Parse(bootSettingsTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse boot settings %s template", kind)
+	nodeSettings.PKI[name] = pkiSettings{
+		Data:    base64.StdEncoding.EncodeToString([]byte(data)),
+		Trusted: true,
 	}
-	if _, err := tm.Parse(certsTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse certs %s template", kind)
+}
+
+// generateAdminContainerUserData returns the user data of the admin container, which authorizes the ssh keys of all
+// the users. It is empty if no user has ssh keys.
+func generateAdminContainerUserData(users []bootstrapv1.User) ([]byte, error) {
+	var sshAuthorizedKeys []string
+	for _, user := range users {
+		sshAuthorizedKeys = append(sshAuthorizedKeys, user.SSHAuthorizedKeys...)
 	}
-	if _, err := tm.Parse(certBundlesSliceTemplate); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse cert bundles %s template", kind)
+	if len(sshAuthorizedKeys) == 0 {
+		return nil, nil
 	}
-	t, err := tm.Parse(tpl)
+	data, err := json.Marshal(adminContainerUserData{SSH: adminContainerSSH{AuthorizedKeys: sshAuthorizedKeys}})
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse %s template", kind)
+		return nil, errors.Wrap(err, "failed to generate admin container user data")
+	}
+	return data, nil
+}
+
+// encodeSettings returns nodeSettings with overrides merged over them, encoded in TOML without indentation.
+func encodeSettings(nodeSettings settings, overrides *apiextensionsv1.JSON) ([]byte, error) {
+	var userData interface{} = userDataSettings{Settings: nodeSettings}
+	if overrides != nil {
+		merged, err := mergeSettingsOverrides(nodeSettings, overrides)
+		if err != nil {
+			return nil, err
+		}
+		userData = map[string]interface{}{"settings": merged}
 	}
 
 	var out bytes.Buffer
-	if err := t.Execute(&out, data); err != nil {
-		return nil, errors.Wrapf(err, "failed to generate %s template", kind)
+	encoder := toml.NewEncoder(&out)
+	encoder.Indent = ""
+	if err := encoder.Encode(userData); err != nil {
+		return nil, errors.Wrap(err, "failed to encode bottlerocket settings")
 	}
 	return out.Bytes(), nil
 }
@@ -4,14 +4,17 @@ import (
 	"path/filepath"
 	"strings"
 
-	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
-	"github.com/go-logr/logr"
 )
 
 const (
 	orgCertsPath = "/etc/etcd/pki"
 	newCertsPath = "/var/lib/etcd/pki"
+	// hostRootPath is where superpowered host containers find the root filesystem of the host
+	hostRootPath = "/.bottlerocket/rootfs"
+	// backupCertsPath is where superpowered host containers find the etcd certificates
+	backupCertsPath = hostRootPath + newCertsPath
 )
 
 func prepare(input *userdata.BaseUserData) {
@@ -51,18 +54,3 @@ func splitRepositoryAndTag(image string) (repository, tag string) {
 
 	return image[:lastInd], image[lastInd+1:]
 }
-
-func logIgnoredFields(input *userdata.BaseUserData, log logr.Logger) {
-	if len(input.PreEtcdadmCommands) > 0 {
-		log.Info("Ignoring PreEtcdadmCommands. Not supported with bottlerocket")
-	}
-	if len(input.PostEtcdadmCommands) > 0 {
-		log.Info("Ignoring PostEtcdadmCommands. Not supported with bottlerocket")
-	}
-	if input.DiskSetup != nil {
-		log.Info("Ignoring DiskSetup. Not supported with bottlerocket")
-	}
-	if len(input.Mounts) > 0 {
-		log.Info("Ignoring Mounts. Not supported with bottlerocket")
-	}
-}
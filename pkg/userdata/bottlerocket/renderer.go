@@ -0,0 +1,55 @@
+package bottlerocket
+
+import (
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Renderer renders Bottlerocket bootstrap data.
+type Renderer struct{}
+
+var _ userdata.Renderer = Renderer{}
+
+// RenderInit returns the Bottlerocket settings of the machine initializing the etcd cluster.
+func (Renderer) RenderInit(input *userdata.EtcdPlaneInput, config etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error) {
+	return NewInitEtcdPlane(input, config, log)
+}
+
+// RenderJoin returns the Bottlerocket settings of a machine joining the etcd cluster.
+func (Renderer) RenderJoin(input *userdata.EtcdPlaneJoinInput, config etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error) {
+	return NewJoinEtcdPlane(input, config, log)
+}
+
+// Validate requires the images of the Bottlerocket host containers running etcd, and of the containers taking backups
+// and joining members as learners when they are enabled.
+func (Renderer) Validate(config etcdbootstrapv1.EtcdadmConfigSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if config.BottlerocketConfig == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("bottlerocketConfig"), "is required for the bottlerocket format"))
+	} else if config.JoinAsLearner && config.BottlerocketConfig.LearnerImage == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("bottlerocketConfig", "learnerImage"), "is required to join members as learners with the bottlerocket format"))
+	}
+	if config.Backup != nil && config.Backup.Image == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("backup", "image"), "is required for the bottlerocket format"))
+	}
+	if config.RegistryMirror != nil {
+		for i, mirror := range config.RegistryMirror.Mirrors {
+			if mirror.InsecureSkipVerify {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("registryMirror", "mirrors").Index(i).Child("insecureSkipVerify"), "is not supported for the bottlerocket format"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// Capabilities returns the optional fields Bottlerocket supports. etcdadm is built in the bootstrap container and
+// there is no shell to run commands in, backups and learner joins run in containers of their own. Its single proxy
+// setting is the HTTPS proxy, or the HTTP proxy when there is none.
+func (Renderer) Capabilities() userdata.Capabilities {
+	return userdata.Capabilities{
+		JoinAsLearner: true,
+		Backup:        true,
+	}
+}
@@ -0,0 +1,184 @@
+package bottlerocket
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// userDataSettings is the user data of a Bottlerocket host, as read by its API server. Tables are encoded in field
+// order and map entries sorted by key, so identical settings always encode to the same TOML.
+type userDataSettings struct {
+	Settings settings `toml:"settings"`
+}
+
+type settings struct {
+	HostContainers      map[string]hostContainer      `toml:"host-containers"`
+	Kubernetes          kubernetesSettings            `toml:"kubernetes"`
+	Network             networkSettings               `toml:"network"`
+	DNS                 *dnsSettings                  `toml:"dns,omitempty"`
+	BootstrapContainers map[string]bootstrapContainer `toml:"bootstrap-containers,omitempty"`
+	ContainerRegistry   *containerRegistrySettings    `toml:"container-registry,omitempty"`
+	PKI                 map[string]pkiSettings        `toml:"pki,omitempty"`
+	NTP                 *ntpSettings                  `toml:"ntp,omitempty"`
+	Kernel              *kernelSettings               `toml:"kernel,omitempty"`
+	Boot                *bootSettings                 `toml:"boot,omitempty"`
+}
+
+type hostContainer struct {
+	Enabled      bool   `toml:"enabled"`
+	Superpowered bool   `toml:"superpowered"`
+	Source       string `toml:"source,omitempty"`
+	UserData     string `toml:"user-data,omitempty"`
+}
+
+type bootstrapContainer struct {
+	Essential bool   `toml:"essential"`
+	Mode      string `toml:"mode"`
+	Source    string `toml:"source,omitempty"`
+	UserData  string `toml:"user-data,omitempty"`
+}
+
+type kubernetesSettings struct {
+	ClusterDomain          string `toml:"cluster-domain"`
+	StandaloneMode         bool   `toml:"standalone-mode"`
+	AuthenticationMode     string `toml:"authentication-mode"`
+	ServerTLSBootstrap     bool   `toml:"server-tls-bootstrap"`
+	PodInfraContainerImage string `toml:"pod-infra-container-image"`
+}
+
+type networkSettings struct {
+	Hostname string `toml:"hostname"`
+	// HTTPSProxy is the only proxy setting of Bottlerocket, used for both HTTP and HTTPS.
+	HTTPSProxy string   `toml:"https-proxy,omitempty"`
+	NoProxy    []string `toml:"no-proxy,omitempty"`
+	// Hosts are [ip, [hostnames]] pairs.
+	Hosts [][]interface{} `toml:"hosts,omitempty"`
+}
+
+type dnsSettings struct {
+	NameServers []string `toml:"name-servers,omitempty"`
+	SearchList  []string `toml:"search-list,omitempty"`
+}
+
+type containerRegistrySettings struct {
+	Mirrors     map[string][]string           `toml:"mirrors,omitempty"`
+	Credentials []containerRegistryCredential `toml:"credentials,omitempty"`
+}
+
+type containerRegistryCredential struct {
+	Registry string `toml:"registry"`
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+}
+
+type pkiSettings struct {
+	Data    string `toml:"data"`
+	Trusted bool   `toml:"trusted"`
+}
+
+type ntpSettings struct {
+	TimeServers []string `toml:"time-servers"`
+}
+
+type kernelSettings struct {
+	Sysctl map[string]string `toml:"sysctl"`
+}
+
+type bootSettings struct {
+	RebootToReconcile bool                `toml:"reboot-to-reconcile"`
+	KernelParameters  map[string][]string `toml:"kernel-parameters"`
+}
+
+// adminContainerUserData is the user data of the admin host container.
+type adminContainerUserData struct {
+	SSH adminContainerSSH `json:"ssh"`
+}
+
+type adminContainerSSH struct {
+	AuthorizedKeys []string `json:"authorized-keys"`
+}
+
+// mergeSettingsOverrides returns the generic TOML values of nodeSettings with overrides merged over them.
+func mergeSettingsOverrides(nodeSettings settings, overrides *apiextensionsv1.JSON) (map[string]interface{}, error) {
+	generated, err := toml.Marshal(nodeSettings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode bottlerocket settings")
+	}
+	merged := map[string]interface{}{}
+	if err := toml.Unmarshal(generated, &merged); err != nil {
+		return nil, errors.Wrap(err, "failed to decode generated bottlerocket settings")
+	}
+	decoded, err := decodeSettingsOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	mergeSettings(merged, decoded)
+	return merged, nil
+}
+
+// decodeSettingsOverrides returns the Bottlerocket settings of a spec as TOML values: JSON numbers are integers unless
+// they have a fraction or exponent.
+func decodeSettingsOverrides(overrides *apiextensionsv1.JSON) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(overrides.Raw))
+	decoder.UseNumber()
+	var decoded map[string]interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, errors.Wrap(err, "failed to decode bottlerocket settings")
+	}
+	values, err := tomlValue(decoded)
+	if err != nil {
+		return nil, err
+	}
+	return values.(map[string]interface{}), nil
+}
+
+func tomlValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, errors.New("bottlerocket settings cannot be null")
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case []interface{}:
+		for i := range v {
+			value, err := tomlValue(v[i])
+			if err != nil {
+				return nil, err
+			}
+			v[i] = value
+		}
+	case map[string]interface{}:
+		for k := range v {
+			value, err := tomlValue(v[k])
+			if err != nil {
+				return nil, err
+			}
+			v[k] = value
+		}
+	}
+	return v, nil
+}
+
+// mergeSettings merges overrides into generated. Tables are merged key by key, any other value in overrides replaces
+// the one in generated.
+func mergeSettings(generated, overrides map[string]interface{}) {
+	for k, override := range overrides {
+		overrideTable, ok := override.(map[string]interface{})
+		if !ok {
+			generated[k] = override
+			continue
+		}
+		generatedTable, ok := generated[k].(map[string]interface{})
+		if !ok {
+			generated[k] = overrideTable
+			continue
+		}
+		mergeSettings(generatedTable, overrideTable)
+	}
+}
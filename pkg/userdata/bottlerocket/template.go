@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"text/template"
 
-	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -23,13 +23,13 @@ var defaultTemplateFuncMap = template.FuncMap{
 	"Indent": userdata.TemplateYAMLIndent,
 }
 
-func generateUserData(kind string, tpl string, data interface{}, input *userdata.BaseUserData, config etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error) {
+func generateUserData(kind string, tpl string, data interface{}, input *userdata.BaseUserData, learner *learnerUserData, config etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error) {
 	bootstrapContainerUserData, err := generateBootstrapContainerUserData(kind, tpl, data)
 	if err != nil {
 		return nil, err
 	}
 
-	return generateBottlerocketNodeUserData(bootstrapContainerUserData, input.Users, input.RegistryMirrorCredentials, input.Hostname, config, log)
+	return generateBottlerocketNodeUserData(bootstrapContainerUserData, input.Users, input.RegistryMirrorCredentials, input.BackupCredentials, input.Hostname, learner, config, log)
 }
 
 func generateBootstrapContainerUserData(kind string, tpl string, data interface{}) ([]byte, error) {
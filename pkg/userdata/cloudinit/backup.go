@@ -0,0 +1,146 @@
+package cloudinit
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"text/template"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+)
+
+const (
+	defaultInstallDir = "/opt/bin"
+	etcdCertsDir      = "/etc/etcd/pki"
+	backupDir         = "/etc/etcd/backup"
+
+	backupScript = `#!/bin/bash
+set -euo pipefail
+
+name="etcd-snapshot-$(hostname)-$(date -u +%Y%m%dT%H%M%SZ).db"
+mkdir -p "${BACKUP_DIR}"
+ETCDCTL_API=3 "${ETCDCTL}" --endpoints=https://127.0.0.1:2379 \
+  --cacert="${ETCD_CACERT}" --cert="${ETCD_CERT}" --key="${ETCD_KEY}" \
+  snapshot save "${BACKUP_DIR}/${name}"
+
+if [ -n "${S3_ENDPOINT:-}" ]; then
+  s3() {
+    curl --fail --silent --show-error --aws-sigv4 "aws:amz:${S3_REGION}:s3" \
+      --user "${AWS_ACCESS_KEY_ID}:${AWS_SECRET_ACCESS_KEY}" "$@"
+  }
+  s3 --upload-file "${BACKUP_DIR}/${name}" "${S3_ENDPOINT}/${S3_BUCKET}/${S3_PREFIX}${name}"
+  if [ "${BACKUP_RETENTION}" -gt 0 ]; then
+    s3 "${S3_ENDPOINT}/${S3_BUCKET}?list-type=2&prefix=${S3_PREFIX}etcd-snapshot-$(hostname)-" \
+      | grep -o '<Key>[^<]*</Key>' | sed -e 's/<Key>//' -e 's/<\/Key>//' | sort -r \
+      | tail -n +$((BACKUP_RETENTION + 1)) \
+      | while read -r key; do s3 -X DELETE "${S3_ENDPOINT}/${S3_BUCKET}/${key}"; done
+  fi
+  if [ "${BACKUP_KEEP_LOCAL}" != "true" ]; then
+    rm -f "${BACKUP_DIR}/${name}"
+  fi
+fi
+
+if [ "${BACKUP_RETENTION}" -gt 0 ]; then
+  find "${BACKUP_DIR}" -maxdepth 1 -name 'etcd-snapshot-*.db' | sort -r \
+    | tail -n +$((BACKUP_RETENTION + 1)) | xargs -r rm -f --
+fi
+`
+
+	backupService = `[Unit]
+Description=Save an etcd snapshot
+After=etcd.service
+
+[Service]
+Type=oneshot
+EnvironmentFile={{.EnvironmentFile}}
+ExecStart={{.Script}}
+`
+
+	backupTimer = `[Unit]
+Description=Save etcd snapshots on a schedule
+
+[Timer]
+OnCalendar={{.Schedule}}
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`
+)
+
+type backupUnitInput struct {
+	EnvironmentFile string
+	Script          string
+	Schedule        string
+}
+
+func setBackup(backup *etcdbootstrapv1.BackupConfiguration, cloudInitConfig *etcdbootstrapv1.CloudInitConfig, input *userdata.BaseUserData) error {
+	if backup == nil {
+		return nil
+	}
+
+	installDir := defaultInstallDir
+	if cloudInitConfig != nil && cloudInitConfig.InstallDir != "" {
+		installDir = cloudInitConfig.InstallDir
+	}
+
+	unitInput := backupUnitInput{
+		EnvironmentFile: filepath.Join(backupDir, "etcd-backup.env"),
+		Script:          filepath.Join(backupDir, "etcd-backup.sh"),
+		Schedule:        userdata.BackupSchedule(backup),
+	}
+
+	service, err := renderBackupUnit("backupService", backupService, unitInput)
+	if err != nil {
+		return err
+	}
+	timer, err := renderBackupUnit("backupTimer", backupTimer, unitInput)
+	if err != nil {
+		return err
+	}
+
+	environment := userdata.BackupEnvironment(backup, filepath.Join(installDir, "etcdctl"), etcdCertsDir, "", input.BackupCredentials)
+
+	input.AdditionalFiles = append(input.AdditionalFiles,
+		capbk.File{
+			Content:     backupScript,
+			Owner:       "root:root",
+			Permissions: "0700",
+			Path:        unitInput.Script,
+		},
+		capbk.File{
+			Content:     environment,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Path:        unitInput.EnvironmentFile,
+		},
+		capbk.File{
+			Content: service,
+			Owner:   "root:root",
+			Path:    "/etc/systemd/system/etcd-backup.service",
+		},
+		capbk.File{
+			Content: timer,
+			Owner:   "root:root",
+			Path:    "/etc/systemd/system/etcd-backup.timer",
+		},
+	)
+
+	input.PostEtcdadmCommands = append(input.PostEtcdadmCommands, "systemctl daemon-reload", "systemctl enable --now etcd-backup.timer")
+	return nil
+}
+
+func renderBackupUnit(name, tpl string, data backupUnitInput) (string, error) {
+	t, err := template.New(name).Parse(tpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %v", name, err)
+	}
+
+	var out bytes.Buffer
+	if err = t.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("error generating %s file: %v", name, err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,61 @@
+package cloudinit
+
+import (
+	"path/filepath"
+
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+)
+
+const (
+	// caCertificatesDir holds a <name>.crt file for each cert bundle, which trustCACertificatesScript adds to the
+	// trust store of the machine.
+	caCertificatesDir = "/etc/etcdadm/ca-certificates"
+
+	// trustCACertificatesScript copies the cert bundles to the anchors directory of the distro family of the machine
+	// and rebuilds its trust store: update-ca-trust on RHEL and its derivatives, update-ca-certificates on Debian,
+	// Ubuntu and SUSE.
+	trustCACertificatesScript = `#!/bin/bash
+set -euo pipefail
+
+if command -v update-ca-trust >/dev/null && [ -d /etc/pki/ca-trust/source/anchors ]; then
+  cp ` + caCertificatesDir + `/*.crt /etc/pki/ca-trust/source/anchors/
+  update-ca-trust extract
+elif command -v update-ca-certificates >/dev/null && [ -d /usr/local/share/ca-certificates ]; then
+  cp ` + caCertificatesDir + `/*.crt /usr/local/share/ca-certificates/
+  update-ca-certificates
+elif command -v update-ca-certificates >/dev/null && [ -d /etc/pki/trust/anchors ]; then
+  cp ` + caCertificatesDir + `/*.crt /etc/pki/trust/anchors/
+  update-ca-certificates
+else
+  echo "cannot find the trust store of the machine to add the CA certificates of ` + caCertificatesDir + ` to" >&2
+  exit 1
+fi
+`
+)
+
+// setCertBundles adds the cert bundles to the trust store of the machine before any other command runs, so that the
+// etcd release, snapshots and the pre etcdadm commands can be downloaded from servers signed by them.
+func setCertBundles(certBundles []capbk.CertBundle, input *userdata.BaseUserData) {
+	if len(certBundles) == 0 {
+		return
+	}
+
+	for _, bundle := range certBundles {
+		input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+			Content:     bundle.Data,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Path:        filepath.Join(caCertificatesDir, bundle.Name+".crt"),
+		})
+	}
+
+	script := "/etc/etcdadm/trust-ca-certificates.sh"
+	input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+		Content:     trustCACertificatesScript,
+		Owner:       "root:root",
+		Permissions: "0700",
+		Path:        script,
+	})
+	input.PreEtcdadmCommands = append([]string{script}, input.PreEtcdadmCommands...)
+}
@@ -3,12 +3,12 @@ package cloudinit
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 	"text/template"
 
-	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
 	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
-	"github.com/pkg/errors"
 	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
 )
 
@@ -18,7 +18,9 @@ const (
 	// sentinelFileCommand writes a file to /run/cluster-api to signal successful Kubernetes bootstrapping in a way that
 	// works both for Linux and Windows OS.
 	sentinelFileCommand = "echo success > /run/cluster-api/bootstrap-success.complete"
-	cloudConfigHeader   = `## template: jinja
+	// cloudConfigHeader marks the cloud-config as a jinja template, so cloud-init renders the jinja syntax of the
+	// commands, files and users of the spec. Literal jinja syntax has to be escaped with {% raw %}.
+	cloudConfigHeader = `## template: jinja
 #cloud-config
 `
 	proxyConf = `
@@ -27,68 +29,16 @@ Environment="HTTP_PROXY={{.HTTPProxy}}"
 Environment="HTTPS_PROXY={{.HTTPSProxy}}"
 Environment="NO_PROXY={{ stringsJoin .NoProxy "," }}"
 `
-	registryMirrorConf = `
-[plugins."io.containerd.grpc.v1.cri".registry.mirrors]
-  [plugins."io.containerd.grpc.v1.cri".registry.mirrors."public.ecr.aws"]
-    endpoint = ["https://{{.Endpoint}}"]
-  [plugins."io.containerd.grpc.v1.cri".registry.configs."{{.Endpoint}}".tls]
-  {{- if not .CACert }}
-    insecure_skip_verify = true
-  {{- else }}
-    ca_file = "/etc/containerd/certs.d/{{.Endpoint}}/ca.crt"
-  {{- end }}
+	// proxyEnvironmentFile holds the proxy variables sourced before etcdadm is installed and run.
+	proxyEnvironmentFile = "/etc/etcdadm/proxy.env"
+	// machineNoProxy appends the addresses of the machine to NO_PROXY when proxyEnvironmentFile is sourced.
+	machineNoProxy = `NO_PROXY="${NO_PROXY},$(ip -o addr show 2>/dev/null | awk '{sub(/\/.*/, "", $4); print $4}' | paste -sd , -)"
+no_proxy="${NO_PROXY}"
 `
 )
 
 var containerdRestart = []string{"sudo systemctl daemon-reload", "sudo systemctl restart containerd"}
 
-var defaultTemplateFuncMap = template.FuncMap{
-	"Indent": userdata.TemplateYAMLIndent,
-}
-
-func generate(kind string, tpl string, data interface{}) ([]byte, error) {
-	tm := template.New(kind).Funcs(defaultTemplateFuncMap)
-	if _, err := tm.Parse(filesTemplate); err != nil {
-		return nil, errors.Wrap(err, "failed to parse files template")
-	}
-
-	if _, err := tm.Parse(commandsTemplate); err != nil {
-		return nil, errors.Wrap(err, "failed to parse commands template")
-	}
-
-	if _, err := tm.Parse(ntpTemplate); err != nil {
-		return nil, errors.Wrap(err, "failed to parse ntp template")
-	}
-
-	if _, err := tm.Parse(usersTemplate); err != nil {
-		return nil, errors.Wrap(err, "failed to parse users template")
-	}
-
-	if _, err := tm.Parse(diskSetupTemplate); err != nil {
-		return nil, errors.Wrap(err, "failed to parse disk setup template")
-	}
-
-	if _, err := tm.Parse(fsSetupTemplate); err != nil {
-		return nil, errors.Wrap(err, "failed to parse fs setup template")
-	}
-
-	if _, err := tm.Parse(mountsTemplate); err != nil {
-		return nil, errors.Wrap(err, "failed to parse mounts template")
-	}
-
-	t, err := tm.Parse(tpl)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse %s template", kind)
-	}
-
-	var out bytes.Buffer
-	if err := t.Execute(&out, data); err != nil {
-		return nil, errors.Wrapf(err, "failed to generate %s template", kind)
-	}
-
-	return out.Bytes(), nil
-}
-
 func prepare(input *userdata.BaseUserData) error {
 	input.Header = cloudConfigHeader
 	input.WriteFiles = append(input.WriteFiles, input.AdditionalFiles...)
@@ -105,7 +55,9 @@ func buildEtcdadmArgs(config etcdbootstrapv1.EtcdadmConfigSpec) userdata.Etcdadm
 	}
 }
 
-func setProxy(proxy *etcdbootstrapv1.ProxyConfiguration, input *userdata.BaseUserData) error {
+// setProxy makes containerd, etcdadm and the commands installing it use proxy. directHosts are the hosts etcdadm
+// reaches without going through the proxy, on top of the NoProxy entries of proxy.
+func setProxy(proxy *etcdbootstrapv1.ProxyConfiguration, directHosts []string, input *userdata.BaseUserData) error {
 	if proxy == nil {
 		return nil
 	}
@@ -127,38 +79,47 @@ func setProxy(proxy *etcdbootstrapv1.ProxyConfiguration, input *userdata.BaseUse
 	})
 
 	input.PreEtcdadmCommands = append(input.PreEtcdadmCommands, containerdRestart...)
+	setProxyEnvironment(proxy, directHosts, input)
 	return nil
 }
 
-func setRegistryMirror(registryMirror *etcdbootstrapv1.RegistryMirrorConfiguration, input *userdata.BaseUserData) error {
-	if registryMirror == nil {
-		return nil
-	}
-	tmpl := template.New("registryMirror")
-	t, err := tmpl.Parse(registryMirrorConf)
-	if err != nil {
-		return fmt.Errorf("failed to parse registryMirror template: %v", err)
-	}
-
-	var out bytes.Buffer
-	if err = t.Execute(&out, registryMirror); err != nil {
-		return fmt.Errorf("error generating registryMirror config file: %v", err)
-	}
-
-	input.AdditionalFiles = append(input.AdditionalFiles,
-		capbk.File{
-			Content: registryMirror.CACert,
-			Owner:   "root:root",
-			Path:    fmt.Sprintf("/etc/containerd/certs.d/%s/ca.crt", registryMirror.Endpoint),
-		},
-		capbk.File{
-			Content: out.String(),
-			Owner:   "root:root",
-			Path:    "/etc/containerd/config_append.toml",
-		},
-	)
+// setProxyEnvironment exports the proxy variables to every command of the cloud-config, which cloud-init runs as a
+// single script, so that the etcdadm install commands and etcdadm itself download through the proxy. Both letter cases
+// are set, as curl only reads http_proxy in lower case.
+//
+// etcdadm also reads the variables when it connects to etcd, so localhost, directHosts and the addresses of the machine,
+// only known once it boots, are always added to NO_PROXY, whether or not the NoProxy entries of proxy list them.
+func setProxyEnvironment(proxy *etcdbootstrapv1.ProxyConfiguration, directHosts []string, input *userdata.BaseUserData) {
+	if proxy.HTTPProxy == "" && proxy.HTTPSProxy == "" {
+		return
+	}
+
+	noProxy := slices.Clone(proxy.NoProxy)
+	for _, host := range append([]string{"localhost", "127.0.0.1"}, directHosts...) {
+		if !slices.Contains(noProxy, host) {
+			noProxy = append(noProxy, host)
+		}
+	}
+
+	var env strings.Builder
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", proxy.HTTPProxy},
+		{"HTTPS_PROXY", proxy.HTTPSProxy},
+		{"NO_PROXY", strings.Join(noProxy, ",")},
+	} {
+		if v.value == "" {
+			continue
+		}
+		fmt.Fprintf(&env, "%s=%s\n", v.name, userdata.ShellQuote(v.value))
+		fmt.Fprintf(&env, "%s=%s\n", strings.ToLower(v.name), userdata.ShellQuote(v.value))
+	}
+	env.WriteString(machineNoProxy)
 
-	input.PreEtcdadmCommands = append(input.PreEtcdadmCommands, `cat /etc/containerd/config_append.toml >> /etc/containerd/config.toml`)
-	input.PreEtcdadmCommands = append(input.PreEtcdadmCommands, containerdRestart...)
-	return nil
+	input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+		Content:     env.String(),
+		Owner:       "root:root",
+		Permissions: "0644",
+		Path:        proxyEnvironmentFile,
+	})
+	input.PreEtcdadmCommands = append([]string{"set -a && . " + proxyEnvironmentFile + " && set +a"}, input.PreEtcdadmCommands...)
 }
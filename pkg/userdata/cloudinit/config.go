@@ -0,0 +1,177 @@
+package cloudinit
+
+import (
+	"bytes"
+
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/pkg/errors"
+	"go.yaml.in/yaml/v3"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+)
+
+// placeholderFile creates the /run/cluster-api sub directory the sentinel file is written to.
+var placeholderFile = writeFile{
+	Path:        "/run/cluster-api/placeholder",
+	Owner:       "root:root",
+	Permissions: "0640",
+	Content:     "This placeholder file is used to create the /run/cluster-api sub directory in a way that is compatible with both Linux and Windows (mkdir -p /run/cluster-api does not work with Windows)",
+}
+
+// cloudConfig is the cloud-config of an etcd machine. It is marshalled rather than templated, so that every value
+// taken from the spec is encoded as a YAML scalar and cannot add keys to the document.
+type cloudConfig struct {
+	// BootCmd runs on every boot, before the other modules.
+	BootCmd    []string                      `yaml:"bootcmd,omitempty"`
+	WriteFiles []writeFile                   `yaml:"write_files"`
+	RunCmd     []string                      `yaml:"runcmd"`
+	NTP        *ntp                          `yaml:"ntp,omitempty"`
+	Users      []user                        `yaml:"users,omitempty"`
+	DiskSetup  map[string]diskSetupPartition `yaml:"disk_setup,omitempty"`
+	FSSetup    []fsSetup                     `yaml:"fs_setup,omitempty"`
+	Mounts     [][]string                    `yaml:"mounts,omitempty"`
+	// ManageResolvConf makes cloud-init write ResolvConf to /etc/resolv.conf.
+	ManageResolvConf bool        `yaml:"manage_resolv_conf,omitempty"`
+	ResolvConf       *resolvConf `yaml:"resolv_conf,omitempty"`
+}
+
+type resolvConf struct {
+	NameServers   []string `yaml:"nameservers,omitempty"`
+	SearchDomains []string `yaml:"searchdomains,omitempty"`
+}
+
+type writeFile struct {
+	Path        string `yaml:"path"`
+	Encoding    string `yaml:"encoding,omitempty"`
+	Owner       string `yaml:"owner,omitempty"`
+	Permissions string `yaml:"permissions,omitempty"`
+	Append      bool   `yaml:"append,omitempty"`
+	Content     string `yaml:"content"`
+}
+
+type ntp struct {
+	Enabled bool     `yaml:"enabled,omitempty"`
+	Servers []string `yaml:"servers"`
+}
+
+type user struct {
+	Name              string   `yaml:"name"`
+	Passwd            *string  `yaml:"passwd,omitempty"`
+	Gecos             *string  `yaml:"gecos,omitempty"`
+	Groups            *string  `yaml:"groups,omitempty"`
+	HomeDir           *string  `yaml:"homedir,omitempty"`
+	Inactive          bool     `yaml:"inactive,omitempty"`
+	LockPassword      *bool    `yaml:"lock_passwd,omitempty"`
+	Shell             *string  `yaml:"shell,omitempty"`
+	PrimaryGroup      *string  `yaml:"primary_group,omitempty"`
+	Sudo              *string  `yaml:"sudo,omitempty"`
+	SSHAuthorizedKeys []string `yaml:"ssh_authorized_keys,omitempty"`
+}
+
+type diskSetupPartition struct {
+	TableType *string `yaml:"table_type,omitempty"`
+	Layout    bool    `yaml:"layout"`
+	Overwrite *bool   `yaml:"overwrite,omitempty"`
+}
+
+type fsSetup struct {
+	Label      string   `yaml:"label"`
+	Filesystem string   `yaml:"filesystem"`
+	Device     string   `yaml:"device"`
+	Partition  *string  `yaml:"partition,omitempty"`
+	Overwrite  *bool    `yaml:"overwrite,omitempty"`
+	ReplaceFS  *string  `yaml:"replace_fs,omitempty"`
+	ExtraOpts  []string `yaml:"extra_opts,omitempty"`
+}
+
+// newCloudConfig returns the cloud-config of input, running etcdadmCommand between the pre and post etcdadm commands.
+func newCloudConfig(input *userdata.BaseUserData, etcdadmCommand string) *cloudConfig {
+	config := &cloudConfig{}
+
+	for _, f := range input.WriteFiles {
+		config.WriteFiles = append(config.WriteFiles, newWriteFile(f))
+	}
+	config.WriteFiles = append(config.WriteFiles, placeholderFile)
+
+	config.RunCmd = append(config.RunCmd, input.PreEtcdadmCommands...)
+	config.RunCmd = append(config.RunCmd, etcdadmCommand+" && "+input.SentinelFileCommand)
+	config.RunCmd = append(config.RunCmd, input.PostEtcdadmCommands...)
+
+	if input.NTP != nil {
+		config.NTP = &ntp{
+			Enabled: input.NTP.Enabled != nil && *input.NTP.Enabled,
+			Servers: input.NTP.Servers,
+		}
+	}
+
+	for _, u := range input.Users {
+		config.Users = append(config.Users, user{
+			Name:              u.Name,
+			Passwd:            u.Passwd,
+			Gecos:             u.Gecos,
+			Groups:            u.Groups,
+			HomeDir:           u.HomeDir,
+			Inactive:          u.Inactive != nil && *u.Inactive,
+			LockPassword:      u.LockPassword,
+			Shell:             u.Shell,
+			PrimaryGroup:      u.PrimaryGroup,
+			Sudo:              u.Sudo,
+			SSHAuthorizedKeys: u.SSHAuthorizedKeys,
+		})
+	}
+
+	if input.DiskSetup != nil {
+		for _, p := range input.DiskSetup.Partitions {
+			if config.DiskSetup == nil {
+				config.DiskSetup = map[string]diskSetupPartition{}
+			}
+			config.DiskSetup[p.Device] = diskSetupPartition{
+				TableType: p.TableType,
+				Layout:    p.Layout,
+				Overwrite: p.Overwrite,
+			}
+		}
+		for _, fs := range input.DiskSetup.Filesystems {
+			config.FSSetup = append(config.FSSetup, fsSetup{
+				Label:      fs.Label,
+				Filesystem: fs.Filesystem,
+				Device:     fs.Device,
+				Partition:  fs.Partition,
+				Overwrite:  fs.Overwrite,
+				ReplaceFS:  fs.ReplaceFS,
+				ExtraOpts:  fs.ExtraOpts,
+			})
+		}
+	}
+
+	for _, m := range input.Mounts {
+		config.Mounts = append(config.Mounts, []string(m))
+	}
+
+	return config
+}
+
+func newWriteFile(f capbk.File) writeFile {
+	return writeFile{
+		Path:        f.Path,
+		Encoding:    string(f.Encoding),
+		Owner:       f.Owner,
+		Permissions: f.Permissions,
+		Append:      f.Append,
+		Content:     f.Content,
+	}
+}
+
+// generate returns config marshalled after header.
+func generate(header string, config *cloudConfig) ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteString(header)
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal cloud-config")
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal cloud-config")
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,83 @@
+package cloudinit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+)
+
+const (
+	// sysctlFile holds the sysctl settings of the spec, so that they are also applied when the machine reboots.
+	sysctlFile = "/etc/sysctl.d/90-etcdadm.conf"
+
+	// bootKernelParametersCommand adds the parameters in $args to the kernel command line with grubby on RHEL and its
+	// derivatives, or with a /etc/default/grub.d file on Debian and Ubuntu, and reboots the machine once. cloud-init
+	// runs bootcmd on every boot before the other modules, so the rest of the cloud-config only runs after the
+	// reboot. If the parameters are still missing after it, the machine is not rebooted again.
+	bootKernelParametersCommand = `set -f
+marker=/var/lib/etcdadm/kernel-parameters-rebooted
+missing=
+for arg in $args; do
+  tr ' ' '\n' < /proc/cmdline | grep -qxF -- "$arg" || missing="$missing $arg"
+done
+if [ -n "$missing" ] && [ -e "$marker" ]; then
+  echo "kernel parameters$missing are missing from the kernel command line after rebooting" >&2
+elif [ -n "$missing" ]; then
+  if command -v grubby >/dev/null; then
+    grubby --update-kernel=ALL --args="$args"
+  elif command -v update-grub >/dev/null; then
+    mkdir -p /etc/default/grub.d
+    echo "GRUB_CMDLINE_LINUX=\"\$GRUB_CMDLINE_LINUX $args\"" > /etc/default/grub.d/90-etcdadm.cfg
+    update-grub
+  else
+    echo "cannot find grubby or update-grub to add the kernel parameters$missing with" >&2
+  fi
+  mkdir -p "$(dirname "$marker")" && touch "$marker"
+  systemctl reboot && sleep infinity
+fi
+set +f`
+)
+
+// setSysctlSettings writes the sysctl settings of kernel to sysctlFile and applies them before etcdadm runs.
+func setSysctlSettings(kernel *etcdbootstrapv1.KernelConfiguration, input *userdata.BaseUserData) {
+	if kernel == nil || len(kernel.SysctlSettings) == 0 {
+		return
+	}
+
+	var settings strings.Builder
+	for _, key := range slices.Sorted(maps.Keys(kernel.SysctlSettings)) {
+		fmt.Fprintf(&settings, "%s = %s\n", key, kernel.SysctlSettings[key])
+	}
+	input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+		Content:     settings.String(),
+		Owner:       "root:root",
+		Permissions: "0644",
+		Path:        sysctlFile,
+	})
+	input.PreEtcdadmCommands = append(input.PreEtcdadmCommands, "sysctl -p "+sysctlFile)
+}
+
+// setBootKernelParameters makes cloud-init add the boot parameters of kernel to the kernel command line, rebooting the
+// machine before the rest of the cloud-config runs.
+func setBootKernelParameters(kernel *etcdbootstrapv1.KernelConfiguration, config *cloudConfig) {
+	if kernel == nil || len(kernel.BootKernelParameters) == 0 {
+		return
+	}
+
+	var args []string
+	for _, key := range slices.Sorted(maps.Keys(kernel.BootKernelParameters)) {
+		values := kernel.BootKernelParameters[key]
+		if len(values) == 0 {
+			args = append(args, key)
+		}
+		for _, value := range values {
+			args = append(args, key+"="+value)
+		}
+	}
+	config.BootCmd = append(config.BootCmd, "args="+userdata.ShellQuote(strings.Join(args, " "))+"\n"+bootKernelParametersCommand)
+}
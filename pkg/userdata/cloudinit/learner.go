@@ -0,0 +1,54 @@
+package cloudinit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+)
+
+const (
+	learnerDir = "/etc/etcd/learner"
+
+	// learnerScript adds the member as a learner before running the etcdadm join command passed to it. The script
+	// fails as soon as the join does, and otherwise promotes the learner once it has caught up with the leader.
+	learnerScript = `#!/bin/bash
+set -euo pipefail
+
+endpoint="$1"
+shift
+
+pki="` + etcdCertsDir + `"
+` + userdata.LearnerFunctions + `
+add_learner
+
+status=0
+"$@" || status=$?
+if [ "${status}" -ne 0 ]; then
+  echo "etcdadm join exited with ${status}" >&2
+  exit "${status}"
+fi
+
+deadline=$((SECONDS + ` + userdata.LearnerPromotionTimeout + `))
+until promote; do
+  if [ "${SECONDS}" -ge "${deadline}" ]; then
+    echo "${peer_url} was not promoted to a voting member in time" >&2
+    exit 1
+  fi
+  sleep 5
+done
+`
+)
+
+// setLearner makes the member join the cluster as a learner and wait for its promotion.
+func setLearner(input *userdata.EtcdPlaneJoinInput) {
+	script := filepath.Join(learnerDir, "etcd-learner.sh")
+	input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+		Content:     learnerScript,
+		Owner:       "root:root",
+		Permissions: "0700",
+		Path:        script,
+	})
+	input.EtcdadmJoinCommand = fmt.Sprintf("%s %s %s", script, input.JoinAddress, input.EtcdadmJoinCommand)
+}
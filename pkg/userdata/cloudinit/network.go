@@ -0,0 +1,42 @@
+package cloudinit
+
+import (
+	"fmt"
+	"strings"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+)
+
+// setHosts appends the static host entries of network to /etc/hosts.
+func setHosts(network *etcdbootstrapv1.NetworkConfiguration, input *userdata.BaseUserData) {
+	if network == nil || len(network.Hosts) == 0 {
+		return
+	}
+
+	var hosts strings.Builder
+	for _, host := range network.Hosts {
+		fmt.Fprintf(&hosts, "%s %s\n", host.IP, strings.Join(host.Hostnames, " "))
+	}
+	input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+		Content:     hosts.String(),
+		Owner:       "root:root",
+		Permissions: "0644",
+		Path:        "/etc/hosts",
+		Append:      true,
+	})
+}
+
+// setResolvConf makes cloud-init write the name servers and search domains of network to /etc/resolv.conf.
+func setResolvConf(network *etcdbootstrapv1.NetworkConfiguration, config *cloudConfig) {
+	if network == nil || (len(network.NameServers) == 0 && len(network.SearchDomains) == 0) {
+		return
+	}
+
+	config.ManageResolvConf = true
+	config.ResolvConf = &resolvConf{
+		NameServers:   network.NameServers,
+		SearchDomains: network.SearchDomains,
+	}
+}
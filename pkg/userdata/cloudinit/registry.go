@@ -0,0 +1,130 @@
+package cloudinit
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/pkg/errors"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+)
+
+const (
+	// registryHostsDir is where containerd reads the hosts.toml file of each registry from.
+	registryHostsDir = "/etc/containerd/certs.d"
+
+	// registryConfigPathScript points containerd 1.x at registryHostsDir, by setting config_path in the registry table
+	// of the CRI plugin in place. containerd 2.x reads registryHostsDir by default. The version of containerd is the
+	// first argument, or read from containerd when it is not passed.
+	registryConfigPathScript = `#!/bin/bash
+set -euo pipefail
+
+version="${1:-$(containerd --version | awk '{print $3}')}"
+version="${version#v}"
+if [ "${version%%.*}" -ge 2 ] 2>/dev/null; then
+  exit 0
+fi
+
+config=/etc/containerd/config.toml
+table='^\s*\[plugins\."io\.containerd\.grpc\.v1\.cri"\.registry\]'
+config_path='config_path = "` + registryHostsDir + `"'
+
+if sed -n "/${table}/,/^\s*\[/p" "${config}" | grep -Eq '^\s*config_path\s*='; then
+  sed -i -E "/${table}/,/^\s*\[/ s|^(\s*)config_path\s*=.*|\1${config_path}|" "${config}"
+elif grep -Eq "${table}" "${config}"; then
+  sed -i -E "/${table}/a\  ${config_path}" "${config}"
+else
+  echo "${config} has no registry table to set the config_path of containerd ${version} in" >&2
+  exit 1
+fi
+`
+)
+
+// registryHosts is the hosts.toml of an upstream registry, which makes containerd pull its images from a mirror.
+type registryHosts struct {
+	Server string                  `toml:"server"`
+	Host   map[string]registryHost `toml:"host"`
+}
+
+type registryHost struct {
+	Capabilities []string `toml:"capabilities"`
+	CA           string   `toml:"ca,omitempty"`
+	SkipVerify   bool     `toml:"skip_verify,omitempty"`
+	// OverridePath makes containerd use the path of the host as the registry API root, rather than appending /v2.
+	OverridePath bool `toml:"override_path,omitempty"`
+}
+
+// setRegistryMirror writes the hosts.toml file of each mirrored registry, along with the CA certificates of the mirrors,
+// and makes sure containerd reads them before etcdadmCommand runs.
+func setRegistryMirror(registryMirror *etcdbootstrapv1.RegistryMirrorConfiguration, cloudInitConfig *etcdbootstrapv1.CloudInitConfig, input *userdata.BaseUserData, etcdadmCommand *string) error {
+	mirrors := userdata.RegistryMirrors(registryMirror)
+	if len(mirrors) == 0 {
+		return nil
+	}
+
+	var caFiles []string
+	for _, mirror := range mirrors {
+		host := registryHost{
+			Capabilities: []string{"pull", "resolve"},
+			SkipVerify:   mirror.InsecureSkipVerify,
+			OverridePath: mirror.PathPrefix != "",
+		}
+		if mirror.CACert != "" && !mirror.InsecureSkipVerify {
+			host.CA = filepath.Join(registryHostsDir, mirror.Endpoint, "ca.crt")
+			if !slices.Contains(caFiles, host.CA) {
+				caFiles = append(caFiles, host.CA)
+				input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+					Content: mirror.CACert,
+					Owner:   "root:root",
+					Path:    host.CA,
+				})
+			}
+		}
+
+		var out bytes.Buffer
+		encoder := toml.NewEncoder(&out)
+		encoder.Indent = ""
+		if err := encoder.Encode(registryHosts{
+			Server: registryServer(mirror.Upstream),
+			Host:   map[string]registryHost{userdata.RegistryMirrorURL(mirror): host},
+		}); err != nil {
+			return errors.Wrapf(err, "failed to encode the hosts.toml of registry %s", mirror.Upstream)
+		}
+		input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+			Content:     out.String(),
+			Owner:       "root:root",
+			Permissions: "0644",
+			Path:        filepath.Join(registryHostsDir, mirror.Upstream, "hosts.toml"),
+		})
+	}
+
+	script := "/etc/containerd/set-registry-config-path.sh"
+	input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+		Content:     registryConfigPathScript,
+		Owner:       "root:root",
+		Permissions: "0700",
+		Path:        script,
+	})
+	command := script
+	if cloudInitConfig != nil && cloudInitConfig.ContainerdVersion != "" {
+		command = fmt.Sprintf("%s %s", script, userdata.ShellQuote(cloudInitConfig.ContainerdVersion))
+	}
+	// cloud-init carries on after failed commands, so the script is chained to etcdadm for the machine not to be
+	// bootstrapped with a containerd that ignores the mirrors
+	commands := append([]string{command}, containerdRestart...)
+	*etcdadmCommand = strings.Join(append(commands, *etcdadmCommand), " && ")
+	return nil
+}
+
+// registryServer returns the URL of upstream, which containerd falls back to when its mirror fails.
+func registryServer(upstream string) string {
+	if upstream == "docker.io" {
+		return "https://registry-1.docker.io"
+	}
+	return "https://" + upstream
+}
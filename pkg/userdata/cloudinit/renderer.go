@@ -0,0 +1,48 @@
+package cloudinit
+
+import (
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const stopKubeletCommand = "systemctl stop kubelet"
+
+// Renderer renders cloud-config bootstrap data.
+type Renderer struct{}
+
+var _ userdata.Renderer = Renderer{}
+
+// RenderInit returns the cloud-config of the machine initializing the etcd cluster.
+func (Renderer) RenderInit(input *userdata.EtcdPlaneInput, config etcdbootstrapv1.EtcdadmConfigSpec, _ logr.Logger) ([]byte, error) {
+	input.PreEtcdadmCommands = append(input.PreEtcdadmCommands, stopKubeletCommand)
+	return NewInitEtcdPlane(input, config)
+}
+
+// RenderJoin returns the cloud-config of a machine joining the etcd cluster.
+func (Renderer) RenderJoin(input *userdata.EtcdPlaneJoinInput, config etcdbootstrapv1.EtcdadmConfigSpec, _ logr.Logger) ([]byte, error) {
+	input.PreEtcdadmCommands = append(input.PreEtcdadmCommands, stopKubeletCommand)
+	return NewJoinEtcdPlane(input, config)
+}
+
+// Validate requires the cloud-init settings etcdadm is run with.
+func (Renderer) Validate(config etcdbootstrapv1.EtcdadmConfigSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if config.CloudInitConfig == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("cloudInitConfig"), "is required for the cloud-config format"))
+	}
+	return allErrs
+}
+
+// Capabilities returns the optional fields cloud-config supports, which are all of them.
+func (Renderer) Capabilities() userdata.Capabilities {
+	return userdata.Capabilities{
+		EtcdadmCommands: true,
+		EtcdadmInstall:  true,
+		RestoreFrom:     true,
+		JoinAsLearner:   true,
+		Backup:          true,
+		HTTPProxy:       true,
+	}
+}
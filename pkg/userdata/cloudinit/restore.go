@@ -0,0 +1,49 @@
+package cloudinit
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	capbk "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+)
+
+const restoreDir = "/var/lib/etcd-restore"
+
+// setRestore makes the init machine fetch and verify the snapshot before etcdadm runs.
+// etcdadm restores the snapshot into the member's data directory (the equivalent of
+// `etcdutl snapshot restore`) before it starts the member when given the --snapshot flag.
+func setRestore(restore *etcdbootstrapv1.RestoreConfiguration, input *userdata.EtcdPlaneInput) error {
+	if restore == nil {
+		return nil
+	}
+
+	snapshotPath := filepath.Join(restoreDir, "snapshot.db")
+	switch {
+	case restore.URL != "":
+		input.PreEtcdadmCommands = append(input.PreEtcdadmCommands,
+			fmt.Sprintf("mkdir -p %s", restoreDir),
+			fmt.Sprintf("curl --fail --silent --show-error --location --retry 5 --output %s %q", snapshotPath, restore.URL),
+		)
+	case len(input.RestoreSnapshot) > 0:
+		input.AdditionalFiles = append(input.AdditionalFiles, capbk.File{
+			Content:     base64.StdEncoding.EncodeToString(input.RestoreSnapshot),
+			Encoding:    capbk.Base64,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Path:        snapshotPath,
+		})
+	default:
+		return fmt.Errorf("no snapshot to restore the etcd cluster from")
+	}
+
+	if restore.SHA256 != "" {
+		input.PreEtcdadmCommands = append(input.PreEtcdadmCommands, fmt.Sprintf("echo %q | sha256sum --check --strict", restore.SHA256+"  "+snapshotPath))
+	}
+	input.EtcdadmArgs.SnapshotPath = snapshotPath
+	// the snapshot holds the full keyspace, do not leave it behind on the node
+	input.PostEtcdadmCommands = append(input.PostEtcdadmCommands, fmt.Sprintf("rm -rf %s", restoreDir))
+	return nil
+}
@@ -16,6 +16,8 @@ type EtcdPlaneInput struct {
 	EtcdadmArgs
 
 	EtcdadmInitCommand string
+	// RestoreSnapshot holds the snapshot the cluster is restored from when it is sourced from a Secret or ConfigMap.
+	RestoreSnapshot []byte
 }
 
 // EtcdPlaneJoinInput defines context to generate etcd instance user data for etcd plane node join.
@@ -43,6 +45,7 @@ type BaseUserData struct {
 	SentinelFileCommand string
 	Hostname            string
 	RegistryMirrorCredentials
+	BackupCredentials
 }
 
 type EtcdadmArgs struct {
@@ -51,11 +54,37 @@ type EtcdadmArgs struct {
 	EtcdReleaseURL  string
 	InstallDir      string
 	CipherSuites    string
+	SnapshotPath    string
 }
 
 type RegistryMirrorCredentials struct {
 	Username string
 	Password string
+	// Registries are the credentials of each registry, read from a kubernetes.io/dockerconfigjson Secret. Registries
+	// without credentials of their own use Username and Password.
+	Registries map[string]RegistryCredentials
+}
+
+// RegistryCredentials are the credentials of one registry.
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// For returns the credentials of registry, and whether there are any.
+func (c RegistryMirrorCredentials) For(registry string) (RegistryCredentials, bool) {
+	if credentials, ok := c.Registries[registry]; ok {
+		return credentials, true
+	}
+	if c.Username != "" && c.Password != "" {
+		return RegistryCredentials{Username: c.Username, Password: c.Password}, true
+	}
+	return RegistryCredentials{}, false
+}
+
+type BackupCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
 }
 
 func (args *EtcdadmArgs) SystemdFlags() []string {
@@ -73,6 +102,9 @@ func (args *EtcdadmArgs) SystemdFlags() []string {
 	if args.CipherSuites != "" {
 		flags = append(flags, fmt.Sprintf("--cipher-suites %s", args.CipherSuites))
 	}
+	if args.SnapshotPath != "" {
+		flags = append(flags, fmt.Sprintf("--snapshot %s", args.SnapshotPath))
+	}
 	return flags
 }
 
@@ -0,0 +1,59 @@
+package userdata
+
+const (
+	// LearnerFunctions defines the shell functions adding a joining member as a learner and promoting it, for scripts
+	// setting $endpoint to the client URL of the member to join through and $pki to the directory holding the etcd CA.
+	// They sign a short-lived client certificate with the CA, and call the cluster API of etcd with curl.
+	LearnerFunctions = `workdir="$(mktemp -d)"
+trap 'rm -rf "${workdir}"' EXIT
+
+# etcdadm advertises the first global address of the interface holding the default route
+interface="$(ip -4 route show default | awk '{print $5; exit}')"
+address="$(ip -4 -o addr show dev "${interface}" scope global | awk '{split($4, a, "/"); print a[1]; exit}')"
+peer_url="https://${address}:2380"
+
+openssl req -new -newkey rsa:2048 -nodes -subj "/CN=etcd-learner" \
+  -keyout "${workdir}/client.key" -out "${workdir}/client.csr" 2>/dev/null
+echo "extendedKeyUsage=clientAuth" > "${workdir}/client.ext"
+openssl x509 -req -days 1 -in "${workdir}/client.csr" -CA "${pki}/ca.crt" -CAkey "${pki}/ca.key" \
+  -CAcreateserial -CAserial "${workdir}/ca.srl" -extfile "${workdir}/client.ext" \
+  -out "${workdir}/client.crt" 2>/dev/null
+
+cluster() {
+  curl --fail --silent --show-error --cacert "${pki}/ca.crt" \
+    --cert "${workdir}/client.crt" --key "${workdir}/client.key" \
+    -X POST "${endpoint}/v3/cluster/member/$1" -d "$2"
+}
+
+member() {
+  members="$(cluster list '{}')" || return 1
+  echo "${members}" | tr '{' '\n' | grep -F "\"${peer_url}\"" || true
+}
+
+# add_learner adds the member as a learner, unless it is already part of the cluster. etcdadm finds the member by its
+# peer URL and skips adding it again.
+add_learner() {
+  current="$(member)"
+  if [ -z "${current}" ]; then
+    echo "adding ${peer_url} as a learner"
+    cluster add "{\"peerURLs\":[\"${peer_url}\"],\"isLearner\":true}" > /dev/null
+  fi
+}
+
+# promote succeeds once the member is a voting member, promoting it when it is a learner that has caught up with the
+# leader.
+promote() {
+  current="$(member)" && [ -n "${current}" ] || return 1
+  if ! echo "${current}" | grep -q '"isLearner":true'; then
+    echo "${peer_url} is a voting member"
+    return 0
+  fi
+  id="$(echo "${current}" | sed -n 's/.*"ID":"\([0-9]*\)".*/\1/p')"
+  cluster promote "{\"ID\":\"${id}\"}" > /dev/null 2>&1 || return 1
+  echo "promoted learner ${id}"
+}
+`
+
+	// LearnerPromotionTimeout is how long, in seconds, a learner may take to catch up with the leader.
+	LearnerPromotionTimeout = "600"
+)
@@ -0,0 +1,21 @@
+package userdata
+
+import (
+	"net/url"
+	"strings"
+)
+
+// JoinAddressHosts returns the hosts of the comma-separated client URLs of joinAddress, which machines joining the
+// etcd cluster reach directly.
+func JoinAddressHosts(joinAddress string) []string {
+	var hosts []string
+	if joinAddress == "" {
+		return hosts
+	}
+	for _, clientURL := range strings.Split(joinAddress, ",") {
+		if u, err := url.Parse(clientURL); err == nil && u.Hostname() != "" {
+			hosts = append(hosts, u.Hostname())
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,38 @@
+package userdata
+
+import (
+	"strings"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+)
+
+// PublicECRRegistry is the upstream registry RegistryMirrorConfiguration.Endpoint mirrors.
+const PublicECRRegistry = "public.ecr.aws"
+
+// RegistryMirrors returns the mirrors of config: public.ecr.aws mirrored to its Endpoint, if set, followed by its
+// Mirrors. The certificate of the Endpoint is not verified when it has no CA certificate.
+func RegistryMirrors(config *etcdbootstrapv1.RegistryMirrorConfiguration) []etcdbootstrapv1.RegistryMirror {
+	if config == nil {
+		return nil
+	}
+	var mirrors []etcdbootstrapv1.RegistryMirror
+	if config.Endpoint != "" {
+		mirrors = append(mirrors, etcdbootstrapv1.RegistryMirror{
+			Upstream:           PublicECRRegistry,
+			Endpoint:           config.Endpoint,
+			CACert:             config.CACert,
+			InsecureSkipVerify: config.CACert == "",
+		})
+	}
+	return append(mirrors, config.Mirrors...)
+}
+
+// RegistryMirrorURL returns the URL the images of the upstream registry of mirror are pulled from. Registries only
+// add the /v2 API prefix to URLs without a path, so it is spelled out when the mirror has a path prefix.
+func RegistryMirrorURL(mirror etcdbootstrapv1.RegistryMirror) string {
+	url := "https://" + mirror.Endpoint
+	if prefix := strings.Trim(mirror.PathPrefix, "/"); prefix != "" {
+		url += "/v2/" + prefix
+	}
+	return url
+}
@@ -0,0 +1,113 @@
+// Package render turns an EtcdadmConfig into the bootstrap data of an etcd machine. It is shared by the controller
+// and the etcdadm-bootstrap CLI so that both produce the exact same output.
+package render
+
+import (
+	"path/filepath"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/bottlerocket"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata/cloudinit"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func init() {
+	userdata.Register(etcdbootstrapv1.CloudConfig, cloudinit.Renderer{})
+	userdata.Register(etcdbootstrapv1.Bottlerocket, bottlerocket.Renderer{})
+}
+
+// TODO: replace with etcdadm release
+var defaultEtcdadmInstallCommands = []string{`curl -OL https://github.com/mrajashree/etcdadm-bootstrap-provider/releases/download/v0.0.0/etcdadm`, `chmod +x etcdadm`, `mv etcdadm /usr/local/bin/etcdadm`}
+
+// EtcdCACertificates returns the etcd CA of a cluster, along with where it is written on etcd machines.
+func EtcdCACertificates() secret.Certificates {
+	certificatesDir := "/etc/etcd/pki"
+	certificates := secret.Certificates{
+		&secret.Certificate{
+			Purpose:  secret.ManagedExternalEtcdCA,
+			CertFile: filepath.Join(certificatesDir, "ca.crt"),
+			KeyFile:  filepath.Join(certificatesDir, "ca.key"),
+		},
+	}
+
+	return certificates
+}
+
+// BaseUserData returns the user data of the etcd machine named hostname that comes from spec alone.
+func BaseUserData(spec etcdbootstrapv1.EtcdadmConfigSpec, hostname string) userdata.BaseUserData {
+	return userdata.BaseUserData{
+		Users:               spec.Users,
+		PreEtcdadmCommands:  spec.PreEtcdadmCommands,
+		PostEtcdadmCommands: spec.PostEtcdadmCommands,
+		NTP:                 spec.NTP,
+		Hostname:            hostname,
+	}
+}
+
+// Init returns the bootstrap data of the machine initializing the etcd cluster, in the format of spec.
+func Init(input *userdata.EtcdPlaneInput, spec etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error) {
+	renderer, err := rendererFor(spec)
+	if err != nil {
+		return nil, err
+	}
+	if spec.RestoreFrom != nil && !renderer.Capabilities().RestoreFrom {
+		log.Info("Ignoring restoreFrom. Not supported with " + string(formatOf(spec)))
+	}
+	input.PreEtcdadmCommands = withEtcdadmCommands(input.PreEtcdadmCommands, spec, renderer.Capabilities(), log)
+	return renderer.RenderInit(input, spec, log)
+}
+
+// Join returns the bootstrap data of a machine joining the etcd cluster, in the format of spec.
+func Join(input *userdata.EtcdPlaneJoinInput, spec etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error) {
+	renderer, err := rendererFor(spec)
+	if err != nil {
+		return nil, err
+	}
+	input.PreEtcdadmCommands = withEtcdadmCommands(input.PreEtcdadmCommands, spec, renderer.Capabilities(), log)
+	return renderer.RenderJoin(input, spec, log)
+}
+
+// rendererFor returns the renderer of the format of spec, provided spec can be rendered in it.
+func rendererFor(spec etcdbootstrapv1.EtcdadmConfigSpec) (userdata.Renderer, error) {
+	renderer, err := userdata.RendererFor(spec.Format)
+	if err != nil {
+		return nil, err
+	}
+	if allErrs := renderer.Validate(spec); len(allErrs) > 0 {
+		return nil, errors.Wrapf(allErrs.ToAggregate(), "invalid EtcdadmConfigSpec for format %s", formatOf(spec))
+	}
+	return renderer, nil
+}
+
+// withEtcdadmCommands returns commands with the commands installing etcdadm appended, and reports the fields spec
+// sets that the format ignores.
+func withEtcdadmCommands(commands []string, spec etcdbootstrapv1.EtcdadmConfigSpec, capabilities userdata.Capabilities, log logr.Logger) []string {
+	for _, ignored := range userdata.IgnoredFields(spec, capabilities) {
+		log.Info("Ignoring " + ignored + ". Not supported with " + string(formatOf(spec)))
+	}
+	if !capabilities.EtcdadmInstall {
+		return commands
+	}
+	return withEtcdadmInstallCommands(commands, spec)
+}
+
+func formatOf(spec etcdbootstrapv1.EtcdadmConfigSpec) etcdbootstrapv1.Format {
+	if spec.Format == "" {
+		return etcdbootstrapv1.CloudConfig
+	}
+	return spec.Format
+}
+
+// withEtcdadmInstallCommands appends the commands installing etcdadm to commands, unless etcdadm is baked in the image.
+func withEtcdadmInstallCommands(commands []string, spec etcdbootstrapv1.EtcdadmConfigSpec) []string {
+	if spec.EtcdadmBuiltin {
+		return commands
+	}
+	if len(spec.EtcdadmInstallCommands) > 0 {
+		return append(commands, spec.EtcdadmInstallCommands...)
+	}
+	return append(commands, defaultEtcdadmInstallCommands...)
+}
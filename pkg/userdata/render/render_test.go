@@ -0,0 +1,277 @@
+package render
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/aws/etcdadm-bootstrap-provider/pkg/userdata"
+)
+
+const testFormat etcdbootstrapv1.Format = "test"
+
+// testRenderer renders the name of the machine or the member it joins, and supports none of the optional fields.
+type testRenderer struct{}
+
+func (r testRenderer) RenderInit(input *userdata.EtcdPlaneInput, _ etcdbootstrapv1.EtcdadmConfigSpec, _ logr.Logger) ([]byte, error) {
+	return []byte("init " + input.Hostname), nil
+}
+
+func (r testRenderer) RenderJoin(input *userdata.EtcdPlaneJoinInput, _ etcdbootstrapv1.EtcdadmConfigSpec, _ logr.Logger) ([]byte, error) {
+	return []byte("join " + input.JoinAddress), nil
+}
+
+func (r testRenderer) Validate(config etcdbootstrapv1.EtcdadmConfigSpec) field.ErrorList {
+	if config.CipherSuites == "" {
+		return field.ErrorList{field.Required(field.NewPath("cipherSuites"), "is required for the test format")}
+	}
+	return nil
+}
+
+func (r testRenderer) Capabilities() userdata.Capabilities {
+	return userdata.Capabilities{}
+}
+
+func init() {
+	userdata.Register(testFormat, testRenderer{})
+}
+
+func TestRendererRegistry(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(userdata.Formats()).To(Equal([]etcdbootstrapv1.Format{etcdbootstrapv1.Bottlerocket, etcdbootstrapv1.CloudConfig, testFormat}))
+	renderer, err := userdata.RendererFor("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(renderer.Capabilities().EtcdadmCommands).To(BeTrue())
+	_, err = userdata.RendererFor("windows")
+	g.Expect(err).To(MatchError(ContainSubstring(`no renderer registered for format "windows"`)))
+	g.Expect(func() { userdata.Register(testFormat, testRenderer{}) }).To(Panic())
+}
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         etcdbootstrapv1.EtcdadmConfigSpec
+		wantData     []string
+		wantCommands []string
+		wantLogs     []string
+		wantErr      string
+	}{
+		{
+			name: "renders registered formats",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format:         testFormat,
+				CipherSuites:   "TLS_AES_128_GCM_SHA256",
+				EtcdadmBuiltin: true,
+			},
+			wantData: []string{"init etcd-0"},
+		},
+		{
+			name: "validates the spec for the format",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format: testFormat,
+			},
+			wantErr: "cipherSuites: Required value: is required for the test format",
+		},
+		{
+			name: "reports fields the format does not support",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format:                 testFormat,
+				CipherSuites:           "TLS_AES_128_GCM_SHA256",
+				PreEtcdadmCommands:     []string{"echo pre"},
+				EtcdadmInstallCommands: []string{"echo install"},
+				RestoreFrom:            &etcdbootstrapv1.RestoreConfiguration{URL: "https://snapshots/etcd.db"},
+			},
+			wantLogs: []string{
+				"Ignoring restoreFrom. Not supported with test",
+				"Ignoring preEtcdadmCommands. Not supported with test",
+				"Ignoring etcdadmInstallCommands. Not supported with test",
+			},
+		},
+		{
+			name: "installs etcdadm and stops the kubelet with cloud-config",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				CloudInitConfig: &etcdbootstrapv1.CloudInitConfig{},
+			},
+			wantData:     []string{"#cloud-config", "mv etcdadm /usr/local/bin/etcdadm", "systemctl stop kubelet"},
+			wantCommands: append(append([]string{}, defaultEtcdadmInstallCommands...), "systemctl stop kubelet"),
+		},
+		{
+			name: "installs and runs etcdadm through the proxy with cloud-config",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				CloudInitConfig: &etcdbootstrapv1.CloudInitConfig{},
+				Proxy:           &etcdbootstrapv1.ProxyConfiguration{HTTPSProxy: "http://proxy:3128"},
+			},
+			wantData: []string{"https_proxy='http://proxy:3128'"},
+			wantCommands: append(append(append([]string{"set -a && . /etc/etcdadm/proxy.env && set +a"},
+				defaultEtcdadmInstallCommands...), "systemctl stop kubelet"),
+				"sudo systemctl daemon-reload", "sudo systemctl restart containerd"),
+		},
+		{
+			name: "requires cloud-init settings with cloud-config",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format: etcdbootstrapv1.CloudConfig,
+			},
+			wantErr: "cloudInitConfig: Required value",
+		},
+		{
+			name: "does not install etcdadm with bottlerocket",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format:             etcdbootstrapv1.Bottlerocket,
+				BottlerocketConfig: &etcdbootstrapv1.BottlerocketConfig{},
+			},
+			wantData: []string{"[settings.host-containers.kubeadm-bootstrap]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var logs []string
+			log := funcr.New(func(_, args string) {
+				logs = append(logs, args)
+			}, funcr.Options{})
+			input := &userdata.EtcdPlaneInput{
+				BaseUserData: BaseUserData(tt.spec, "etcd-0"),
+				Certificates: EtcdCACertificates(),
+			}
+			data, err := Init(input, tt.spec, log)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			for _, want := range tt.wantData {
+				g.Expect(string(data)).To(ContainSubstring(want))
+			}
+			if tt.wantCommands != nil {
+				g.Expect(input.PreEtcdadmCommands).To(HaveExactElements(tt.wantCommands))
+			}
+			for _, want := range tt.wantLogs {
+				g.Expect(logs).To(ContainElement(ContainSubstring(want)))
+			}
+		})
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         etcdbootstrapv1.EtcdadmConfigSpec
+		wantErrs     []string
+		wantWarnings []string
+	}{
+		{
+			name: "cloud-config supports every field",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				CloudInitConfig:    &etcdbootstrapv1.CloudInitConfig{},
+				PreEtcdadmCommands: []string{"echo pre"},
+				Proxy:              &etcdbootstrapv1.ProxyConfiguration{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://secure-proxy:3128"},
+				JoinAsLearner:      true,
+				RestoreFrom:        &etcdbootstrapv1.RestoreConfiguration{URL: "https://snapshots/etcd.db", SHA256: strings.Repeat("a", 64)},
+				Backup:             &etcdbootstrapv1.BackupConfiguration{},
+			},
+		},
+		{
+			name:     "formats without renderer",
+			spec:     etcdbootstrapv1.EtcdadmConfigSpec{Format: "windows"},
+			wantErrs: []string{`spec.format: Unsupported value: "windows": supported values: "bottlerocket", "cloud-config", "test"`},
+		},
+		{
+			name: "fields the format does not support",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format:                 testFormat,
+				CipherSuites:           "TLS_AES_128_GCM_SHA256",
+				PreEtcdadmCommands:     []string{"echo pre"},
+				EtcdadmInstallCommands: []string{"echo install"},
+				JoinAsLearner:          true,
+				RestoreFrom:            &etcdbootstrapv1.RestoreConfiguration{URL: "https://snapshots/etcd.db", SHA256: strings.Repeat("a", 64)},
+				Backup:                 &etcdbootstrapv1.BackupConfiguration{},
+			},
+			wantErrs: []string{
+				"spec.restoreFrom: Forbidden: restoring from a snapshot is not supported for the test format",
+				"spec.joinAsLearner: Forbidden: joining members as learners is not supported for the test format",
+				"spec.backup: Forbidden: etcd backups are not supported for the test format",
+			},
+			wantWarnings: []string{
+				"spec.preEtcdadmCommands is ignored with the test format",
+				"spec.etcdadmInstallCommands is ignored with the test format",
+			},
+		},
+		{
+			name: "renderer validation",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format: testFormat,
+			},
+			wantErrs: []string{"spec.cipherSuites: Required value: is required for the test format"},
+		},
+		{
+			name: "bottlerocket containers without image",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format:             etcdbootstrapv1.Bottlerocket,
+				BottlerocketConfig: &etcdbootstrapv1.BottlerocketConfig{},
+				JoinAsLearner:      true,
+				Backup:             &etcdbootstrapv1.BackupConfiguration{},
+				RestoreFrom:        &etcdbootstrapv1.RestoreConfiguration{URL: "https://snapshots/etcd.db", SHA256: strings.Repeat("a", 64)},
+				RegistryMirror: &etcdbootstrapv1.RegistryMirrorConfiguration{
+					Mirrors: []etcdbootstrapv1.RegistryMirror{{Upstream: "docker.io", Endpoint: "harbor.example.com", InsecureSkipVerify: true}},
+				},
+			},
+			wantErrs: []string{
+				"spec.bottlerocketConfig.learnerImage: Required value",
+				"spec.backup.image: Required value",
+				"spec.registryMirror.mirrors[0].insecureSkipVerify: Forbidden",
+				"spec.restoreFrom: Forbidden",
+			},
+		},
+		{
+			name: "bottlerocket with different HTTP and HTTPS proxies",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format:             etcdbootstrapv1.Bottlerocket,
+				BottlerocketConfig: &etcdbootstrapv1.BottlerocketConfig{},
+				Proxy:              &etcdbootstrapv1.ProxyConfiguration{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://secure-proxy:3128"},
+			},
+			wantWarnings: []string{"spec.proxy.httpProxy is ignored with the bottlerocket format"},
+		},
+		{
+			name: "bottlerocket with the same HTTP and HTTPS proxies",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format:             etcdbootstrapv1.Bottlerocket,
+				BottlerocketConfig: &etcdbootstrapv1.BottlerocketConfig{},
+				Proxy:              &etcdbootstrapv1.ProxyConfiguration{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://proxy:3128"},
+			},
+		},
+		{
+			name: "bottlerocket containers with image",
+			spec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format:             etcdbootstrapv1.Bottlerocket,
+				BottlerocketConfig: &etcdbootstrapv1.BottlerocketConfig{LearnerImage: "etcd-learner:latest"},
+				JoinAsLearner:      true,
+				Backup:             &etcdbootstrapv1.BackupConfiguration{Image: "etcd-backup:latest"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := &etcdbootstrapv1.EtcdadmConfig{Spec: tt.spec}
+			warnings, err := config.ValidateCreate(context.TODO(), config)
+			g.Expect(warnings).To(ConsistOf(tt.wantWarnings))
+			if len(tt.wantErrs) == 0 {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			for _, want := range tt.wantErrs {
+				g.Expect(err.Error()).To(ContainSubstring(want))
+			}
+		})
+	}
+}
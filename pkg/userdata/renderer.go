@@ -0,0 +1,143 @@
+package userdata
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Renderer renders the bootstrap data of etcd machines in one format.
+type Renderer interface {
+	// RenderInit returns the bootstrap data of the machine initializing the etcd cluster.
+	RenderInit(input *EtcdPlaneInput, config etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error)
+	// RenderJoin returns the bootstrap data of a machine joining the etcd cluster.
+	RenderJoin(input *EtcdPlaneJoinInput, config etcdbootstrapv1.EtcdadmConfigSpec, log logr.Logger) ([]byte, error)
+	// Validate returns the reasons config cannot be rendered in the format, with paths relative to the spec.
+	Validate(config etcdbootstrapv1.EtcdadmConfigSpec) field.ErrorList
+	// Capabilities returns the optional EtcdadmConfigSpec fields the format supports.
+	Capabilities() Capabilities
+}
+
+// Capabilities declares the optional EtcdadmConfigSpec fields a Renderer supports. The fields a spec sets but the
+// Renderer of its format does not support are reported as ignored.
+type Capabilities struct {
+	// EtcdadmCommands is whether PreEtcdadmCommands and PostEtcdadmCommands are run around etcdadm.
+	EtcdadmCommands bool
+	// EtcdadmInstall is whether etcdadm is installed by commands when it is not built in the machine image.
+	EtcdadmInstall bool
+	// RestoreFrom is whether the etcd cluster can be initialized from a snapshot.
+	RestoreFrom bool
+	// JoinAsLearner is whether members can join the etcd cluster as learners.
+	JoinAsLearner bool
+	// Backup is whether the machines take etcd snapshots.
+	Backup bool
+	// HTTPProxy is whether the HTTP proxy is set apart from the HTTPS proxy. Formats with a single proxy setting only use
+	// it when there is no HTTPS proxy.
+	HTTPProxy bool
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = map[etcdbootstrapv1.Format]Renderer{}
+)
+
+func init() {
+	etcdbootstrapv1.SetFormatValidator(formatValidator{})
+}
+
+// Register makes renderer render the bootstrap data of format. Downstream builds call it from an init function to add
+// formats. It panics if a renderer is already registered for format.
+func Register(format etcdbootstrapv1.Format, renderer Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	if _, ok := renderers[format]; ok {
+		panic("userdata: a renderer is already registered for format " + string(format))
+	}
+	renderers[format] = renderer
+}
+
+// RendererFor returns the renderer registered for format, cloud-config if format is empty.
+func RendererFor(format etcdbootstrapv1.Format) (Renderer, error) {
+	if format == "" {
+		format = etcdbootstrapv1.CloudConfig
+	}
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	renderer, ok := renderers[format]
+	if !ok {
+		return nil, errors.Errorf("no renderer registered for format %q", format)
+	}
+	return renderer, nil
+}
+
+// Formats returns the formats renderers are registered for, sorted.
+func Formats() []etcdbootstrapv1.Format {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	formats := make([]etcdbootstrapv1.Format, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i] < formats[j] })
+	return formats
+}
+
+// formatValidator validates EtcdadmConfigSpecs against the renderer registered for their format.
+type formatValidator struct{}
+
+// ValidateFormat returns the reasons spec cannot be rendered in its format, including the fields it sets that the
+// format does not support, and the fields the format ignores.
+func (formatValidator) ValidateFormat(spec etcdbootstrapv1.EtcdadmConfigSpec) (field.ErrorList, []string) {
+	renderer, err := RendererFor(spec.Format)
+	if err != nil {
+		formats := Formats()
+		supported := make([]string, 0, len(formats))
+		for _, format := range formats {
+			supported = append(supported, string(format))
+		}
+		return field.ErrorList{field.NotSupported(field.NewPath("format"), spec.Format, supported)}, nil
+	}
+
+	format := spec.Format
+	if format == "" {
+		format = etcdbootstrapv1.CloudConfig
+	}
+	allErrs := renderer.Validate(spec)
+	capabilities := renderer.Capabilities()
+	if spec.RestoreFrom != nil && !capabilities.RestoreFrom {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("restoreFrom"), fmt.Sprintf("restoring from a snapshot is not supported for the %s format", format)))
+	}
+	if spec.JoinAsLearner && !capabilities.JoinAsLearner {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("joinAsLearner"), fmt.Sprintf("joining members as learners is not supported for the %s format", format)))
+	}
+	if spec.Backup != nil && !capabilities.Backup {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("backup"), fmt.Sprintf("etcd backups are not supported for the %s format", format)))
+	}
+	return allErrs, IgnoredFields(spec, capabilities)
+}
+
+// IgnoredFields returns the names of the fields spec sets that a format with capabilities ignores when rendering it.
+// RestoreFrom is not one of them, as configs restoring from a snapshot are rejected by formats not supporting it.
+func IgnoredFields(spec etcdbootstrapv1.EtcdadmConfigSpec, capabilities Capabilities) []string {
+	var ignored []string
+	if !capabilities.EtcdadmCommands {
+		if len(spec.PreEtcdadmCommands) > 0 {
+			ignored = append(ignored, "preEtcdadmCommands")
+		}
+		if len(spec.PostEtcdadmCommands) > 0 {
+			ignored = append(ignored, "postEtcdadmCommands")
+		}
+	}
+	if !capabilities.EtcdadmInstall && len(spec.EtcdadmInstallCommands) > 0 {
+		ignored = append(ignored, "etcdadmInstallCommands")
+	}
+	if proxy := spec.Proxy; !capabilities.HTTPProxy && proxy != nil && proxy.HTTPProxy != "" && proxy.HTTPSProxy != "" && proxy.HTTPProxy != proxy.HTTPSProxy {
+		ignored = append(ignored, "proxy.httpProxy")
+	}
+	return ignored
+}
@@ -7,3 +7,8 @@ func TemplateYAMLIndent(i int, input string) string {
 	ident := "\n" + strings.Repeat(" ", i)
 	return strings.Repeat(" ", i) + strings.Join(split, ident)
 }
+
+// ShellQuote returns s single-quoted for a POSIX shell.
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}